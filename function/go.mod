@@ -3,7 +3,6 @@ module github.com/kyma-project/hydroform/function
 go 1.21
 
 require (
-	github.com/docker/cli v24.0.5+incompatible
 	github.com/docker/docker v24.0.9+incompatible
 	github.com/docker/go-connections v0.4.0
 	github.com/golang/mock v1.6.0
@@ -2,12 +2,12 @@ package docker
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
 	"io"
+	"log/slog"
 	"os"
 	"strings"
 
-	"github.com/docker/cli/cli/streams"
 	"github.com/docker/docker/api/types/mount"
 	"github.com/moby/moby/pkg/jsonmessage"
 	"github.com/moby/moby/pkg/stdcopy"
@@ -39,10 +39,20 @@ type RunOpts struct {
 	Commands      []string
 	User          string
 	Mounts        []mount.Mount
+	// Logger receives the logs of the run, such as the progress of the image pull. If nil, nothing is logged.
+	Logger *slog.Logger
+}
+
+func logger(l *slog.Logger) *slog.Logger {
+	if l == nil {
+		return slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return l
 }
 
 func RunContainer(ctx context.Context, c Client, opts RunOpts) (string, error) {
-	body, err := pullAndRun(ctx, c, &container.Config{
+	log := logger(opts.Logger).With("image", opts.Image, "container", opts.ContainerName)
+	body, err := pullAndRun(ctx, c, log, &container.Config{
 		Env:          opts.Envs,
 		ExposedPorts: portSet(opts.Ports),
 		Image:        opts.Image,
@@ -59,16 +69,19 @@ func RunContainer(ctx context.Context, c Client, opts RunOpts) (string, error) {
 
 	err = c.ContainerStart(ctx, body.ID, types.ContainerStartOptions{})
 	if err != nil {
+		log.Error("could not start the container", "id", body.ID, "error", err)
 		return "", err
 	}
 
+	log.Info("container started", "id", body.ID)
 	return body.ID, nil
 }
 
-func pullAndRun(ctx context.Context, c Client, config *container.Config, hostConfig *container.HostConfig,
+func pullAndRun(ctx context.Context, c Client, log *slog.Logger, config *container.Config, hostConfig *container.HostConfig,
 	containerName string) (container.CreateResponse, error) {
 	body, err := c.ContainerCreate(ctx, config, hostConfig, nil, nil, containerName)
 	if apiclient.IsErrNotFound(err) {
+		log.Info("pulling the image")
 		var r io.ReadCloser
 		r, err = c.ImagePull(ctx, config.Image, types.ImagePullOptions{})
 		if err != nil {
@@ -76,17 +89,54 @@ func pullAndRun(ctx context.Context, c Client, config *container.Config, hostCon
 		}
 		defer r.Close()
 
-		streamer := streams.NewOut(os.Stdout)
-		if err = jsonmessage.DisplayJSONMessagesToStream(r, streamer, nil); err != nil {
+		if err = logPull(r, log); err != nil {
+			log.Error("could not pull the image", "error", err)
 			return body, err
 		}
+		log.Info("image pulled")
 
 		body, err = c.ContainerCreate(ctx, config, hostConfig, nil, nil, containerName)
 	}
 	return body, err
 }
 
+// logPull logs the progress messages of an image pull and returns the error reported in the stream, if any.
+func logPull(r io.Reader, log *slog.Logger) error {
+	dec := json.NewDecoder(r)
+	for {
+		var msg jsonmessage.JSONMessage
+		if err := dec.Decode(&msg); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if msg.Error != nil {
+			return msg.Error
+		}
+
+		attrs := []any{"status", msg.Status}
+		if msg.ID != "" {
+			attrs = append(attrs, "layer", msg.ID)
+		}
+		if msg.Progress != nil && msg.Progress.Total > 0 {
+			attrs = append(attrs, "current", msg.Progress.Current, "total", msg.Progress.Total)
+		}
+		log.Debug("image pull", attrs...)
+	}
+}
+
+// FollowRun copies the output of the container to the stdout and stderr of the process until the container stops.
+// It is the only function of the package that writes to the standard streams, and only because the caller asks
+// for the container output there. Everything else goes to the logger.
+//
+// Deprecated: Use FollowRunTo to choose where the output goes.
 func FollowRun(ctx context.Context, c Client, ID string) error {
+	return FollowRunTo(ctx, c, ID, os.Stdout, os.Stderr)
+}
+
+// FollowRunTo copies the output of the container to the given writers until the container stops.
+func FollowRunTo(ctx context.Context, c Client, ID string, stdout, stderr io.Writer) error {
 	buf, err := c.ContainerAttach(ctx, ID, types.ContainerAttachOptions{
 		Stdout: true,
 		Stderr: true,
@@ -97,14 +147,17 @@ func FollowRun(ctx context.Context, c Client, ID string) error {
 	}
 	defer buf.Close()
 
-	_, err = stdcopy.StdCopy(os.Stdout, os.Stderr, buf.Reader)
+	_, err = stdcopy.StdCopy(stdout, stderr, buf.Reader)
 
 	return err
 }
 
+// Stop returns a function that stops the container and passes its progress and errors to log.
+//
+// Deprecated: Use StopContainer, which logs to a structured logger.
 func Stop(ctx context.Context, c Client, ID string, log func(...interface{})) func() {
 	return func() {
-		log(fmt.Sprintf("\r- Removing container %s...\n", ID))
+		log("\r- Removing container " + ID + "...\n")
 		err := c.ContainerStop(ctx, ID, container.StopOptions{})
 		if err != nil {
 			log(err)
@@ -112,6 +165,17 @@ func Stop(ctx context.Context, c Client, ID string, log func(...interface{})) fu
 	}
 }
 
+// StopContainer returns a function that stops the container. The logger can be nil.
+func StopContainer(ctx context.Context, c Client, ID string, l *slog.Logger) func() {
+	log := logger(l).With("container", ID)
+	return func() {
+		log.Info("removing the container")
+		if err := c.ContainerStop(ctx, ID, container.StopOptions{}); err != nil {
+			log.Error("could not stop the container", "error", err)
+		}
+	}
+}
+
 func portSet(ports map[string]string) nat.PortSet {
 	portSet := nat.PortSet{}
 	for from := range ports {
@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"log/slog"
 	"strings"
 	"testing"
 
@@ -324,3 +325,43 @@ func TestStop(t *testing.T) {
 		require.Equal(t, 1, counter)
 	})
 }
+
+func TestStopContainer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	id := "1"
+	ctx := context.Background()
+	mock := mock_docker.NewMockClient(ctrl)
+	mock.EXPECT().ContainerStop(ctx, id, container.StopOptions{}).
+		Return(errors.New("stop: error")).Times(1)
+
+	buf := &bytes.Buffer{}
+	StopContainer(ctx, mock, id, slog.New(slog.NewTextHandler(buf, nil)))()
+
+	require.Contains(t, buf.String(), "msg=\"removing the container\" container=1")
+	require.Contains(t, buf.String(), "level=ERROR msg=\"could not stop the container\" container=1 error=\"stop: error\"")
+}
+
+func TestLogPull(t *testing.T) {
+	t.Run("should log the pull progress", func(t *testing.T) {
+		stream := `{"status":"Pulling from library/node","id":"14"}
+{"status":"Downloading","progressDetail":{"current":512,"total":1024},"id":"a1b2"}
+{"status":"Download complete","id":"a1b2"}`
+		buf := &bytes.Buffer{}
+
+		err := logPull(strings.NewReader(stream), slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+		require.NoError(t, err)
+		require.Equal(t, 3, strings.Count(buf.String(), "msg=\"image pull\""))
+		require.Contains(t, buf.String(), "status=Downloading layer=a1b2 current=512 total=1024")
+	})
+
+	t.Run("should return the error reported in the stream", func(t *testing.T) {
+		stream := `{"errorDetail":{"message":"manifest unknown"},"error":"manifest unknown"}`
+
+		err := logPull(strings.NewReader(stream), logger(nil))
+
+		require.EqualError(t, err, "manifest unknown")
+	})
+}
@@ -78,6 +78,7 @@ func (m *manager) useOperator(ctx context.Context, opr operator.Operator, option
 			DryRun:       m.getDryRunFlag(options.DryRun),
 			Callbacks:    callbacks,
 			WaitForApply: options.WaitForApply,
+			Logger:       options.Logger,
 		},
 	}
 	return newRefs, opr.Apply(ctx, applyOpts)
@@ -89,6 +90,7 @@ func (m *manager) purgeParents(options Options) {
 		Options: operator.Options{
 			DryRun:    m.getDryRunFlag(options.DryRun),
 			Callbacks: options.Callbacks,
+			Logger:    options.Logger,
 		},
 	}
 
@@ -1,6 +1,10 @@
 package manager
 
-import "github.com/kyma-project/hydroform/function/pkg/operator"
+import (
+	"log/slog"
+
+	"github.com/kyma-project/hydroform/function/pkg/operator"
+)
 
 type OnError int
 
@@ -15,4 +19,6 @@ type Options struct {
 	DryRun             bool
 	SetOwnerReferences bool
 	WaitForApply       bool
+	// Logger receives the logs of the operators. If nil, nothing is logged.
+	Logger *slog.Logger
 }
@@ -44,6 +44,7 @@ func buildMatchRemovedAPIRulePredicate(fnName string, items []unstructured.Unstr
 }
 
 func (o apiRuleOperator) Apply(ctx context.Context, opts ApplyOptions) error {
+	opts.Options = opts.withLogging("apply")
 	predicateFn := buildMatchRemovedAPIRulePredicate(o.fnRef, o.genericOperator.items)
 
 	if err := wipeRemoved(ctx, o.genericOperator.Client, predicateFn, opts.Options); err != nil {
@@ -21,6 +21,7 @@ func NewGenericOperator(c client.Client, u ...unstructured.Unstructured) Operato
 }
 
 func (p genericOperator) Apply(ctx context.Context, opts ApplyOptions) error {
+	opts.Options = opts.withLogging("apply")
 	for i := range p.items {
 		p.items[i].SetOwnerReferences(opts.OwnerReferences)
 		// fire pre callbacks
@@ -46,6 +47,7 @@ func (p genericOperator) apply(ctx context.Context, item unstructured.Unstructur
 		return applied, statusEntry, err
 	}
 	if opts.WaitForApply {
+		opts.logger().Debug("waiting for object", "kind", applied.GetKind(), "name", applied.GetName())
 		err = waitForObject(ctx, p.Client, *applied)
 		if err != nil {
 			return applied, statusEntry, err
@@ -55,6 +57,7 @@ func (p genericOperator) apply(ctx context.Context, item unstructured.Unstructur
 }
 
 func (p genericOperator) Delete(ctx context.Context, opts DeleteOptions) error {
+	opts.Options = opts.withLogging("delete")
 	for i := range p.items {
 		// fire pre callbacks
 		if err := fireCallbacks(&p.items[i], nil, opts.Pre...); err != nil {
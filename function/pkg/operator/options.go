@@ -1,7 +1,13 @@
 package operator
 
 import (
+	"io"
+	"log/slog"
+
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kyma-project/hydroform/function/pkg/client"
 )
 
 type Callbacks struct {
@@ -13,6 +19,51 @@ type Options struct {
 	Callbacks
 	DryRun       []string
 	WaitForApply bool
+	// Logger receives the logs of the operation. If nil, nothing is logged.
+	Logger *slog.Logger
+
+	// logging is set once the logging callbacks are added
+	logging bool
+}
+
+func (o Options) logger() *slog.Logger {
+	if o.Logger == nil {
+		return slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return o.Logger
+}
+
+// withLogging returns the options with callbacks that log each object before and after it is applied or deleted.
+func (o Options) withLogging(operation string) Options {
+	if o.logging || o.Logger == nil {
+		return o
+	}
+	log := o.Logger.With("operation", operation)
+
+	pre := func(v interface{}, _ error) error {
+		if u, ok := v.(*unstructured.Unstructured); ok {
+			log.Debug("processing object", "kind", u.GetKind(), "name", u.GetName(), "namespace", u.GetNamespace())
+		}
+		return nil
+	}
+	post := func(v interface{}, err error) error {
+		entry, ok := v.(client.PostStatusEntry)
+		if !ok {
+			return nil
+		}
+		attrs := []any{"kind", entry.GetKind(), "name", entry.GetName(), "namespace", entry.GetNamespace(), "status", entry.StatusType.String()}
+		if err != nil {
+			log.Error("object failed", append(attrs, "error", err)...)
+			return nil
+		}
+		log.Info("object processed", attrs...)
+		return nil
+	}
+
+	o.Pre = append([]Callback{pre}, o.Pre...)
+	o.Post = append([]Callback{post}, o.Post...)
+	o.logging = true
+	return o
 }
 
 type ApplyOptions struct {
@@ -0,0 +1,39 @@
+package operator
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/hydroform/function/pkg/client"
+)
+
+func Test_withLogging(t *testing.T) {
+	t.Run("should not add callbacks without a logger", func(t *testing.T) {
+		opts := Options{}.withLogging("apply")
+		require.Empty(t, opts.Pre)
+		require.Empty(t, opts.Post)
+	})
+
+	t.Run("should log the objects once", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		opts := Options{Logger: slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))}
+		opts = opts.withLogging("apply").withLogging("apply")
+		require.Len(t, opts.Pre, 1)
+		require.Len(t, opts.Post, 1)
+
+		obj := testObj.DeepCopy()
+		obj.SetKind("Function")
+		require.NoError(t, fireCallbacks(obj, nil, opts.Pre...))
+		require.NoError(t, fireCallbacks(client.NewStatusEntryCreated(*obj), nil, opts.Post...))
+		err := errors.New("apply: error")
+		require.Equal(t, err, fireCallbacks(client.NewPostStatusEntryApplyFailed(*obj), err, opts.Post...))
+
+		require.Contains(t, buf.String(), `level=DEBUG msg="processing object" operation=apply kind=Function name=test-obj namespace=test-namespace`)
+		require.Contains(t, buf.String(), `level=INFO msg="object processed" operation=apply kind=Function name=test-obj namespace=test-namespace status=created`)
+		require.Contains(t, buf.String(), `level=ERROR msg="object failed" operation=apply kind=Function name=test-obj namespace=test-namespace status=applyFailed error="apply: error"`)
+	})
+}
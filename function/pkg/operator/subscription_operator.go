@@ -31,11 +31,13 @@ func NewSubscriptionOperator(c client.Client, fnName, fnNamespace string, u ...u
 }
 
 func (t subscriptionOperator) Apply(ctx context.Context, opts ApplyOptions) error {
+	opts.Options = opts.withLogging("apply")
 	predicate := buildMatchRemovedSubscriptionsPredicate(t.fnRef, t.items)
 	return applySubscriptions(ctx, t.Client, predicate, t.items, opts)
 }
 
 func (t subscriptionOperator) Delete(ctx context.Context, opts DeleteOptions) error {
+	opts.Options = opts.withLogging("delete")
 	return deleteSubscriptions(ctx, t.Client, t.items, opts)
 }
 
@@ -115,6 +117,7 @@ func applySubscriptions(ctx context.Context, c client.Client, p predicate, items
 		}
 		applied, statusEntry, err := applyObject(ctx, c, items[i], opts.DryRun)
 		if opts.WaitForApply && applied != nil {
+			opts.logger().Debug("waiting for object", "kind", applied.GetKind(), "name", applied.GetName())
 			err = waitForObject(ctx, c, *applied)
 			if err != nil {
 				return err
@@ -36,7 +36,6 @@ func joinNonEmpty(elems []string, sep string) string {
 type toUnstructured func(obj interface{}) (map[string]interface{}, error)
 
 func NewSubscriptions(cfg workspace.Cfg) ([]unstructured.Unstructured, error) {
-	fmt.Sprintf(string(cfg.SchemaVersion))
 	switch cfg.SchemaVersion {
 	case workspace.SchemaVersionV0:
 		return newSubscriptionsV1alpha1(cfg, runtime.DefaultUnstructuredConverter.ToUnstructured)
@@ -1,6 +1,9 @@
 package action
 
+import "sync"
+
 var (
+	mu     sync.Mutex
 	before Action
 	after  Action
 	args   []interface{}
@@ -12,40 +15,54 @@ type Action interface {
 }
 
 // SetBefore defines which action will be executed before an Hydroform operation.
+//
+// Deprecated: The action is shared by all goroutines and consumed by the next operation of any of them. Use types.WithBefore to set hooks for a single call.
 func SetBefore(a Action) {
+	mu.Lock()
+	defer mu.Unlock()
 	before = a
 }
 
 // Before runs the action set with SetBefore. It is called and evaluated before each Hydroform operation (Provision, Status, Credentials and Deprovision)
 // After running, the set action is cleared.
+//
+// Deprecated: Use types.WithBefore to set hooks for a single call.
 func Before() error {
-	// clear the action after running it
-	defer func() {
-		before = nil
-	}()
+	// take the action and clear it before running it
+	mu.Lock()
+	a, runArgs := before, args
+	before = nil
+	mu.Unlock()
 
-	if before != nil {
-		_, err := before.Run(args...)
+	if a != nil {
+		_, err := a.Run(runArgs...)
 		return err
 	}
 	return nil
 }
 
 // SetAfter defines which action will be executed after an Hydroform operation.
+//
+// Deprecated: The action is shared by all goroutines and consumed by the next operation of any of them. Use types.WithAfter to set hooks for a single call, which also run if the operation fails.
 func SetAfter(a Action) {
+	mu.Lock()
+	defer mu.Unlock()
 	after = a
 }
 
 // After runs the action set with SetAfter. It is called and evaluated after each Hydroform operation if there are no errors (Provision, Status, Credentials and Deprovision)
 // After running, the set action is cleared.
+//
+// Deprecated: Use types.WithAfter to set hooks for a single call.
 func After() error {
-	// clear the action after running it
-	defer func() {
-		after = nil
-	}()
+	// take the action and clear it before running it
+	mu.Lock()
+	a, runArgs := after, args
+	after = nil
+	mu.Unlock()
 
-	if after != nil {
-		_, err := after.Run(args...)
+	if a != nil {
+		_, err := a.Run(runArgs...)
 		return err
 	}
 	return nil
@@ -53,12 +70,20 @@ func After() error {
 
 // SetArgs allows to define arbitrary arguments that Before and After actions will consume.
 // Calling SetArgs a second time clears the args from the previous call.
+//
+// Deprecated: The hooks set with types.WithBefore and types.WithAfter receive the cluster and the provider of the call.
 func SetArgs(a ...interface{}) {
+	mu.Lock()
+	defer mu.Unlock()
 	args = a
 }
 
 // Args returns the defined arguments for the actions
+//
+// Deprecated: The hooks set with types.WithBefore and types.WithAfter receive the cluster and the provider of the call.
 func Args() []interface{} {
+	mu.Lock()
+	defer mu.Unlock()
 	return args
 }
 
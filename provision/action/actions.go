@@ -70,26 +70,50 @@ type Parallel []Action
 // Run executes all actions concurrently with the same input parameters, collects all results and errors and returns them.
 // An error in an action does not stop the execution.
 func (p Parallel) Run(args ...interface{}) (interface{}, error) {
+	return LimitedParallel{Actions: p}.Run(args...)
+}
+
+// LimitedParallel is an action formed by a set of actions that will be run concurrently, with a limit of actions running at the same time.
+// It can be used the same way as any other Action.
+type LimitedParallel struct {
+	Actions []Action
+	// Limit is the maximum number of actions running at the same time. With a limit of 0 all actions run at once.
+	Limit int
+	// FailFast stops the execution on the first error. Actions that are not started yet are skipped, and actions already running are not waited for.
+	FailFast bool
+}
+
+// Run executes the actions concurrently with the same input parameters, collects all results and errors and returns them.
+// Unless FailFast is set, an error in an action does not stop the execution.
+func (p LimitedParallel) Run(args ...interface{}) (interface{}, error) {
 	type resultSet struct {
 		result interface{}
 		err    error
 	}
-	ach := make(chan resultSet, len(p)) // chan is buffered to not block any sender
+	ach := make(chan resultSet, len(p.Actions)) // chan is buffered to not block any sender
 
-	// Run all actions concurrently and send their result and error through a channel
-	for _, a := range p {
+	limit := p.Limit
+	if limit <= 0 || limit > len(p.Actions) {
+		limit = len(p.Actions)
+	}
+
+	// Run the first actions up to the limit concurrently and send their result and error through a channel
+	start := func(a Action) {
 		go func(a Action, ch chan<- resultSet, args ...interface{}) {
 			r := resultSet{}
 			r.result, r.err = a.Run(args...)
 			ch <- r
 		}(a, ach, args...)
-
+	}
+	next := 0
+	for ; next < limit; next++ {
+		start(p.Actions[next])
 	}
 
-	// Collect all results and errors from the channel
+	// Collect all results and errors from the channel and start the next action whenever one finishes
 	results := make([]interface{}, 0)
 	errStr := strings.Builder{}
-	for i := 0; i < len(p); i++ {
+	for i := 0; i < len(p.Actions); i++ {
 		r := <-ach
 
 		if r.result != nil {
@@ -97,8 +121,15 @@ func (p Parallel) Run(args ...interface{}) (interface{}, error) {
 		}
 		if r.err != nil {
 			errStr.WriteString(fmt.Sprintf("\n%s", r.err.Error()))
+			if p.FailFast {
+				break
+			}
 		}
 
+		if next < len(p.Actions) {
+			start(p.Actions[next])
+			next++
+		}
 	}
 
 	var err error
@@ -0,0 +1,152 @@
+package action
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// ErrTimeout is returned by a Timeout action if the wrapped action does not finish in time.
+var ErrTimeout = errors.New("action timed out")
+
+// Backoff returns how long to wait before the given retry. The first retry is number 1.
+type Backoff func(retry int) time.Duration
+
+// ConstantBackoff waits the same duration before each retry.
+func ConstantBackoff(d time.Duration) Backoff {
+	return func(int) time.Duration {
+		return d
+	}
+}
+
+// ExponentialBackoff doubles the wait time with each retry, starting with initial and never waiting longer than maxDelay.
+func ExponentialBackoff(initial, maxDelay time.Duration) Backoff {
+	return func(retry int) time.Duration {
+		d := initial
+		for i := 1; i < retry && d < maxDelay; i++ {
+			d *= 2
+		}
+		if d > maxDelay {
+			return maxDelay
+		}
+		return d
+	}
+}
+
+// Retry is an action that runs another action again until it succeeds or the maximum number of attempts is reached.
+// It can be used the same way as any other Action.
+type Retry struct {
+	Action Action
+	// Attempts is the maximum number of times the action runs. With less than 1 attempt the action runs once.
+	Attempts int
+	// Backoff is the wait time between the attempts. Without a backoff the attempts run immediately one after the other.
+	Backoff Backoff
+	// Logger logs the failed attempts. Without a logger they are not logged.
+	Logger *slog.Logger
+}
+
+// Run executes the action with the given arguments until it succeeds and returns its result.
+// If all attempts fail, the result and the error of the last attempt are returned.
+func (r Retry) Run(args ...interface{}) (interface{}, error) {
+	attempts := r.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var res interface{}
+	var err error
+	for i := 0; i < attempts; i++ {
+		if i > 0 && r.Backoff != nil {
+			time.Sleep(r.Backoff(i))
+		}
+		if res, err = r.Action.Run(args...); err == nil {
+			return res, nil
+		}
+		if r.Logger != nil {
+			r.Logger.Warn("action attempt failed", "attempt", i+1, "attempts", attempts, "error", err)
+		}
+	}
+	return res, fmt.Errorf("action failed after %d attempts: %w", attempts, err)
+}
+
+// Timeout is an action that bounds the runtime of another action.
+// It can be used the same way as any other Action.
+type Timeout struct {
+	Action   Action
+	Duration time.Duration
+}
+
+// Run executes the action with the given arguments and returns its result, or ErrTimeout if it does not finish within the duration.
+// An action that times out cannot be stopped, it keeps running in the background and its result is discarded.
+func (t Timeout) Run(args ...interface{}) (interface{}, error) {
+	type resultSet struct {
+		result interface{}
+		err    error
+	}
+	ch := make(chan resultSet, 1) // chan is buffered to not block the action after a timeout
+
+	go func() {
+		r := resultSet{}
+		r.result, r.err = t.Action.Run(args...)
+		ch <- r
+	}()
+
+	timer := time.NewTimer(t.Duration)
+	defer timer.Stop()
+
+	select {
+	case r := <-ch:
+		return r.result, r.err
+	case <-timer.C:
+		return nil, fmt.Errorf("%w after %s", ErrTimeout, t.Duration)
+	}
+}
+
+// Predicate decides based on the arguments of an action whether it runs.
+type Predicate func(args ...interface{}) bool
+
+// When is an action that runs another action only if the condition is true.
+// It can be used the same way as any other Action.
+type When struct {
+	Condition Predicate
+	Action    Action
+}
+
+// Run executes the action with the given arguments if the condition is true for them. Otherwise it returns no result and no error.
+func (w When) Run(args ...interface{}) (interface{}, error) {
+	if !w.Condition(args...) {
+		return nil, nil
+	}
+	return w.Action.Run(args...)
+}
+
+// Unless is an action that runs another action only if the condition is false.
+// It can be used the same way as any other Action.
+type Unless struct {
+	Condition Predicate
+	Action    Action
+}
+
+// Run executes the action with the given arguments if the condition is false for them. Otherwise it returns no result and no error.
+func (u Unless) Run(args ...interface{}) (interface{}, error) {
+	if u.Condition(args...) {
+		return nil, nil
+	}
+	return u.Action.Run(args...)
+}
+
+// Finally is an action that always runs a cleanup action after another action, whether the action succeeded or failed.
+// It can be used the same way as any other Action.
+type Finally struct {
+	Action  Action
+	Cleanup Action
+}
+
+// Run executes the action and then the cleanup action with the given arguments and returns the result of the action.
+// The errors of both actions are returned together.
+func (f Finally) Run(args ...interface{}) (interface{}, error) {
+	res, err := f.Action.Run(args...)
+	_, cleanupErr := f.Cleanup.Run(args...)
+	return res, errors.Join(err, cleanupErr)
+}
@@ -0,0 +1,155 @@
+package action
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// flaky fails until it ran the given number of times.
+func flaky(failures int, runs *int) Action {
+	return FuncAction(func(args ...interface{}) (interface{}, error) {
+		*runs++
+		if *runs <= failures {
+			return nil, errors.New("temporary failure")
+		}
+		return *runs, nil
+	})
+}
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+
+	runs := 0
+	res, err := Retry{Action: flaky(2, &runs), Attempts: 3, Backoff: ConstantBackoff(time.Millisecond)}.Run()
+	require.NoError(t, err)
+	require.Equal(t, 3, res, "The action should succeed on the third attempt")
+
+	runs = 0
+	var logs bytes.Buffer
+	_, err = Retry{Action: flaky(5, &runs), Attempts: 3, Logger: slog.New(slog.NewTextHandler(&logs, nil))}.Run()
+	require.EqualError(t, err, "action failed after 3 attempts: temporary failure")
+	require.Equal(t, 3, runs)
+	require.Equal(t, 3, strings.Count(logs.String(), "action attempt failed"), "Each failed attempt should be logged")
+
+	runs = 0
+	_, err = Retry{Action: flaky(5, &runs)}.Run()
+	require.Error(t, err)
+	require.Equal(t, 1, runs, "Without attempts the action should run once")
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	t.Parallel()
+
+	b := ExponentialBackoff(time.Second, 5*time.Second)
+	require.Equal(t, time.Second, b(1))
+	require.Equal(t, 2*time.Second, b(2))
+	require.Equal(t, 4*time.Second, b(3))
+	require.Equal(t, 5*time.Second, b(4))
+	require.Equal(t, 5*time.Second, b(10))
+}
+
+func TestTimeout(t *testing.T) {
+	t.Parallel()
+
+	res, err := Timeout{Action: FuncAction(func(args ...interface{}) (interface{}, error) {
+		return args[0], nil
+	}), Duration: time.Second}.Run("fast")
+	require.NoError(t, err)
+	require.Equal(t, "fast", res)
+
+	_, err = Timeout{Action: FuncAction(func(args ...interface{}) (interface{}, error) {
+		time.Sleep(time.Second)
+		return nil, nil
+	}), Duration: 10 * time.Millisecond}.Run()
+	require.ErrorIs(t, err, ErrTimeout)
+}
+
+func TestConditions(t *testing.T) {
+	t.Parallel()
+
+	echo := FuncAction(func(args ...interface{}) (interface{}, error) {
+		return args[0], nil
+	})
+	isProd := Predicate(func(args ...interface{}) bool {
+		return args[0] == "prod"
+	})
+
+	res, err := When{Condition: isProd, Action: echo}.Run("prod")
+	require.NoError(t, err)
+	require.Equal(t, "prod", res)
+
+	res, err = When{Condition: isProd, Action: echo}.Run("dev")
+	require.NoError(t, err)
+	require.Nil(t, res, "The action should be skipped if the condition is false")
+
+	res, err = Unless{Condition: isProd, Action: echo}.Run("dev")
+	require.NoError(t, err)
+	require.Equal(t, "dev", res)
+
+	res, err = Unless{Condition: isProd, Action: echo}.Run("prod")
+	require.NoError(t, err)
+	require.Nil(t, res, "The action should be skipped if the condition is true")
+}
+
+func TestFinally(t *testing.T) {
+	t.Parallel()
+
+	cleaned := false
+	cleanup := FuncAction(func(args ...interface{}) (interface{}, error) {
+		cleaned = true
+		return nil, nil
+	})
+
+	res, err := Finally{Action: FuncAction(func(args ...interface{}) (interface{}, error) {
+		return "done", nil
+	}), Cleanup: cleanup}.Run()
+	require.NoError(t, err)
+	require.Equal(t, "done", res)
+	require.True(t, cleaned)
+
+	cleaned = false
+	_, err = Finally{Action: FuncAction(func(args ...interface{}) (interface{}, error) {
+		return nil, errors.New("failed")
+	}), Cleanup: cleanup}.Run()
+	require.EqualError(t, err, "failed")
+	require.True(t, cleaned, "The cleanup should also run if the action fails")
+}
+
+func TestLimitedParallel(t *testing.T) {
+	t.Parallel()
+
+	var running, maxRunning int32
+	track := FuncAction(func(args ...interface{}) (interface{}, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return 1, nil
+	})
+
+	res, err := LimitedParallel{Actions: []Action{track, track, track, track, track}, Limit: 2}.Run()
+	require.NoError(t, err)
+	require.Len(t, res, 5)
+	require.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(2), "No more actions than the limit should run at the same time")
+
+	var started int32
+	fail := FuncAction(func(args ...interface{}) (interface{}, error) {
+		atomic.AddInt32(&started, 1)
+		return nil, errors.New("failed")
+	})
+	_, err = LimitedParallel{Actions: []Action{fail, fail, fail, fail}, Limit: 1, FailFast: true}.Run()
+	require.EqualError(t, err, "\nfailed")
+	require.Equal(t, int32(1), atomic.LoadInt32(&started), "No action should start after the first error")
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"io/ioutil"
@@ -8,8 +9,6 @@ import (
 
 	hf "github.com/kyma-project/hydroform/provision"
 
-	"github.com/kyma-project/hydroform/provision/action"
-
 	"github.com/kyma-project/hydroform/provision/types"
 )
 
@@ -61,28 +60,27 @@ func main() {
 		ops = append(ops, types.Persistent())
 	}
 
-	action.SetArgs(cluster.Name, provider.Type)
-
-	action.SetBefore(action.FuncAction(func(args ...interface{}) (interface{}, error) {
-		fmt.Printf("Provisioning %s on %s...\n", args[0], args[1])
-		return nil, nil
-	}))
-
-	action.SetAfter(action.FuncAction(func(args ...interface{}) (interface{}, error) {
-		fmt.Printf("Provisioned %s successfully\n", args[0])
-		return nil, nil
-	}))
-	cluster, err := hf.Provision(cluster, provider, ops...)
+	cluster, err := hf.Provision(cluster, provider, append(ops,
+		types.WithBefore(func(_ context.Context, e types.HookEvent) error {
+			fmt.Printf("Provisioning %s on %s...\n", e.Cluster.Name, e.Provider.Type)
+			return nil
+		}),
+		types.WithAfter(func(_ context.Context, e types.HookEvent) error {
+			if e.Err == nil {
+				fmt.Printf("Provisioned %s successfully\n", e.Cluster.Name)
+			}
+			return nil
+		}))...)
 	if err != nil {
 		fmt.Println("Error", err.Error())
 		return
 	}
 
-	action.SetBefore(action.FuncAction(func(args ...interface{}) (interface{}, error) {
-		fmt.Printf("Getting the status of %s\n", args[0])
-		return nil, nil
-	}))
-	status, err := hf.Status(cluster, provider, ops...)
+	status, err := hf.Status(cluster, provider, append(ops,
+		types.WithBefore(func(_ context.Context, e types.HookEvent) error {
+			fmt.Printf("Getting the status of %s\n", e.Cluster.Name)
+			return nil
+		}))...)
 	if err != nil {
 		fmt.Println("Error:", err.Error())
 		return
@@ -90,16 +88,17 @@ func main() {
 
 	fmt.Println("Status:", status.Phase)
 
-	action.SetBefore(action.FuncAction(func(args ...interface{}) (interface{}, error) {
-		fmt.Println("Downloading the kubeconfig")
-		return nil, nil
-	}))
-
-	action.SetAfter(action.FuncAction(func(args ...interface{}) (interface{}, error) {
-		fmt.Println("Kubeconfig downloaded")
-		return nil, nil
-	}))
-	content, err := hf.Credentials(cluster, provider, ops...)
+	content, err := hf.Credentials(cluster, provider, append(ops,
+		types.WithBefore(func(context.Context, types.HookEvent) error {
+			fmt.Println("Downloading the kubeconfig")
+			return nil
+		}),
+		types.WithAfter(func(_ context.Context, e types.HookEvent) error {
+			if e.Err == nil {
+				fmt.Println("Kubeconfig downloaded")
+			}
+			return nil
+		}))...)
 	if err != nil {
 		fmt.Println("Error", err.Error())
 		return
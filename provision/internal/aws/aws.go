@@ -0,0 +1,268 @@
+package aws
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/kyma-project/hydroform/provision/internal/errs"
+	"github.com/kyma-project/hydroform/provision/operator"
+	"github.com/kyma-project/hydroform/provision/provider"
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+const defaultProfile = "default"
+
+// AwsProvisioner implements Provisioner
+// nolint:revive
+type AwsProvisioner struct {
+	provisionOperator operator.Operator
+}
+
+func init() {
+	provider.Register(types.AWS, func(operatorType operator.Type, ops ...types.Option) provider.Provisioner {
+		return New(operatorType, ops...)
+	})
+}
+
+// New creates a new instance of AwsProvisioner.
+func New(operatorType operator.Type, ops ...types.Option) *AwsProvisioner {
+	// parse config
+	os := &types.Options{}
+	for _, o := range ops {
+		o(os)
+	}
+
+	op := operator.New(operatorType, os)
+
+	return &AwsProvisioner{
+		provisionOperator: op,
+	}
+}
+
+// Provision requests provisioning of a new Kubernetes cluster on AWS with the given configurations.
+func (a *AwsProvisioner) Provision(ctx context.Context, cluster *types.Cluster, provider *types.Provider) (*types.Cluster, error) {
+	if err := a.validateInputs(cluster, provider); err != nil {
+		return cluster, err
+	}
+
+	config, err := a.loadConfigurations(cluster, provider)
+	if err != nil {
+		return cluster, err
+	}
+
+	clusterInfo, err := a.provisionOperator.Create(ctx, provider.Type, config)
+	if err != nil {
+		return cluster, errors.Wrap(err, "unable to provision aws cluster")
+	}
+
+	cluster.ClusterInfo = clusterInfo
+	return cluster, nil
+}
+
+// Status returns the ClusterStatus for the requested cluster.
+func (a *AwsProvisioner) Status(ctx context.Context, cluster *types.Cluster, p *types.Provider) (*types.ClusterStatus, error) {
+	if err := a.validateInputs(cluster, p); err != nil {
+		return nil, err
+	}
+
+	cfg, err := a.loadConfigurations(cluster, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.provisionOperator.Status(ctx, cluster.ClusterInfo, p.Type, cfg)
+}
+
+// Credentials returns the Kubeconfig file as a byte array for the requested cluster.
+func (a *AwsProvisioner) Credentials(ctx context.Context, cluster *types.Cluster, p *types.Provider) ([]byte, error) {
+	if err := a.validateInputs(cluster, p); err != nil {
+		return nil, err
+	}
+
+	config, err := a.loadConfigurations(cluster, p)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeconfig, err := a.provisionOperator.Credentials(ctx, cluster.ClusterInfo, p.Type, config)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to fetch aws cluster credentials")
+	}
+
+	return kubeconfig, nil
+}
+
+// Update requests changing an existing cluster on AWS to match the given configurations.
+func (a *AwsProvisioner) Update(ctx context.Context, cluster *types.Cluster, p *types.Provider) (*types.Cluster, error) {
+	if err := a.validateInputs(cluster, p); err != nil {
+		return cluster, err
+	}
+
+	config, err := a.loadConfigurations(cluster, p)
+	if err != nil {
+		return cluster, err
+	}
+
+	clusterInfo, err := a.provisionOperator.Update(ctx, cluster.ClusterInfo, p.Type, config)
+	if err != nil {
+		return cluster, errors.Wrap(err, "unable to update aws cluster")
+	}
+
+	cluster.ClusterInfo = clusterInfo
+	return cluster, nil
+}
+
+// Deprovision requests deprovisioning of an existing cluster on AWS with the given configurations.
+func (a *AwsProvisioner) Deprovision(ctx context.Context, cluster *types.Cluster, p *types.Provider) error {
+	if err := a.validateInputs(cluster, p); err != nil {
+		return err
+	}
+
+	config, err := a.loadConfigurations(cluster, p)
+	if err != nil {
+		return err
+	}
+
+	if err = a.provisionOperator.Delete(ctx, cluster.ClusterInfo, p.Type, config); err != nil {
+		return errors.Wrap(err, "unable to deprovision aws cluster")
+	}
+
+	return nil
+}
+
+func (a *AwsProvisioner) validateInputs(cluster *types.Cluster, provider *types.Provider) error {
+	var errMessage string
+	if cluster.NodeCount < 1 {
+		errMessage += fmt.Sprintf(errs.CannotBeLess, "Cluster.NodeCount", 1)
+	}
+	// Matches the regex for an EKS cluster name.
+	if match, err := regexp.MatchString(`^[0-9A-Za-z][A-Za-z0-9\-_]{0,99}$`, cluster.Name); !match || err != nil {
+		errMessage += fmt.Sprintf(errs.Custom,
+			"Cluster.Name must start with a letter or number followed by up to 99 letters, "+
+				"numbers, hyphens, or underscores")
+	}
+	if cluster.Location == "" {
+		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Cluster.Location")
+	}
+	if cluster.MachineType == "" {
+		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Cluster.MachineType")
+	}
+	if cluster.KubernetesVersion == "" {
+		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Cluster.KubernetesVersion")
+	}
+	if cluster.DiskSizeGB < 0 {
+		errMessage += fmt.Sprintf(errs.CannotBeLess, "Cluster.DiskSizeGB", 0)
+	}
+
+	if provider.CredentialsFilePath == "" {
+		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.CredentialsFilePath")
+	}
+
+	if cfg, err := provider.Config(); err != nil {
+		errMessage += errs.Config(err)
+	} else {
+		errMessage += errs.Config(cfg.Validate())
+	}
+
+	if errMessage != "" {
+		return errors.New("input validation failed with the following information: " + errMessage)
+	}
+
+	return nil
+}
+
+func (a *AwsProvisioner) loadConfigurations(cluster *types.Cluster, provider *types.Provider) (map[string]interface{},
+	error) {
+	config := map[string]interface{}{}
+	config["cluster_name"] = cluster.Name
+	config["node_count"] = cluster.NodeCount
+	config["machine_type"] = cluster.MachineType
+	config["disk_size"] = cluster.DiskSizeGB
+	config["kubernetes_version"] = cluster.KubernetesVersion
+	config["location"] = cluster.Location
+	config["project"] = provider.ProjectName
+
+	custom, err := provider.Configurations()
+	if err != nil {
+		return nil, err
+	}
+
+	profile := defaultProfile
+	if v, ok := custom["profile"].(string); ok && v != "" {
+		profile = v
+	}
+
+	config["access_key_id"], config["secret_access_key"], config["session_token"], err = awsCredentials(provider.CredentialsFilePath, profile)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error loading credentials")
+	}
+
+	for k, v := range custom {
+		config[k] = v
+	}
+
+	return config, nil
+}
+
+// awsCredentials extracts the access keys of a profile from an AWS shared credentials file.
+// The file uses the INI format of the AWS CLI, for example:
+//
+//	[default]
+//	aws_access_key_id = AKID
+//	aws_secret_access_key = SECRET
+func awsCredentials(path, profile string) (accessKeyID, secretAccessKey, sessionToken string, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	found := false
+	section := ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(strings.Trim(line, "[]"))
+			if section == profile {
+				found = true
+			}
+			continue
+		}
+		if section != profile {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "aws_access_key_id":
+			accessKeyID = strings.TrimSpace(value)
+		case "aws_secret_access_key":
+			secretAccessKey = strings.TrimSpace(value)
+		case "aws_session_token":
+			sessionToken = strings.TrimSpace(value)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return
+	}
+
+	if !found {
+		err = errors.Errorf("profile %s not found in the credentials file", profile)
+	} else if accessKeyID == "" || secretAccessKey == "" {
+		err = errors.Errorf("profile %s has no aws_access_key_id or aws_secret_access_key", profile)
+	}
+	return
+}
@@ -0,0 +1,234 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/kyma-project/hydroform/provision/internal/operator/mocks"
+	"github.com/pkg/errors"
+
+	"github.com/kyma-project/hydroform/provision/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testCluster() *types.Cluster {
+	return &types.Cluster{
+		CPU:               1,
+		KubernetesVersion: "1.27",
+		Name:              "hydro-cluster",
+		DiskSizeGB:        30,
+		NodeCount:         2,
+		Location:          "eu-west-1",
+		MachineType:       "m5.xlarge",
+		ClusterInfo:       &types.ClusterInfo{},
+	}
+}
+
+func testProvider(credentials string) *types.Provider {
+	return &types.Provider{
+		Type:                types.AWS,
+		CredentialsFilePath: credentials,
+		CustomConfigurations: map[string]interface{}{
+			"role_arn":      "arn:aws:iam::123456789012:role/eks-cluster",
+			"node_role_arn": "arn:aws:iam::123456789012:role/eks-nodes",
+			"subnet_ids":    []string{"subnet-a", "subnet-b"},
+		},
+	}
+}
+
+func TestValidateInputs(t *testing.T) {
+	a := &AwsProvisioner{}
+
+	cluster := testCluster()
+	provider := testProvider("/path/to/credentials")
+
+	require.NoError(t, a.validateInputs(cluster, provider), "Validation should pass")
+
+	cluster.NodeCount = 0
+	require.Error(t, a.validateInputs(cluster, provider), "Validation should fail when number of nodes is < 1")
+	cluster.NodeCount = 2
+
+	cluster.Name = ""
+	require.Error(t, a.validateInputs(cluster, provider), "Validation should fail when cluster name is empty")
+	cluster.Name = "-invalid-start"
+	require.Error(t, a.validateInputs(cluster, provider), "Validation should fail when cluster name starts with '-'")
+	cluster.Name = "hydro-cluster"
+
+	cluster.Location = ""
+	require.Error(t, a.validateInputs(cluster, provider), "Validation should fail when cluster location is empty")
+	cluster.Location = "eu-west-1"
+
+	cluster.MachineType = ""
+	require.Error(t, a.validateInputs(cluster, provider), "Validation should fail when cluster machine type is empty")
+	cluster.MachineType = "m5.xlarge"
+
+	cluster.KubernetesVersion = ""
+	require.Error(t, a.validateInputs(cluster, provider), "Validation should fail when Kubernetes version is empty")
+	cluster.KubernetesVersion = "1.27"
+
+	provider.CredentialsFilePath = ""
+	require.Error(t, a.validateInputs(cluster, provider), "Validation should fail when credentials file path is empty")
+	provider.CredentialsFilePath = "/path/to/credentials"
+
+	for _, key := range []string{"role_arn", "node_role_arn", "subnet_ids"} {
+		v := provider.CustomConfigurations[key]
+		delete(provider.CustomConfigurations, key)
+		require.Error(t, a.validateInputs(cluster, provider), fmt.Sprintf("Validation should fail when %s is empty", key))
+		provider.CustomConfigurations[key] = v
+	}
+}
+
+func TestLoadConfigurations(t *testing.T) {
+	a := &AwsProvisioner{}
+
+	cluster := testCluster()
+	provider := testProvider("./credentials-load-config")
+
+	err := fakeCredentials(provider.CredentialsFilePath)
+	require.NoError(t, err, "Creating a fake credentials file should not have an error")
+	defer os.Remove(provider.CredentialsFilePath)
+
+	// happy path with the default profile
+	config, err := a.loadConfigurations(cluster, provider)
+	require.NoError(t, err)
+
+	require.Equal(t, cluster.Name, config["cluster_name"])
+	require.Equal(t, "AKID", config["access_key_id"])
+	require.Equal(t, "SECRET", config["secret_access_key"])
+	require.Equal(t, "", config["session_token"])
+	require.Equal(t, cluster.NodeCount, config["node_count"])
+	require.Equal(t, cluster.MachineType, config["machine_type"])
+	require.Equal(t, cluster.DiskSizeGB, config["disk_size"])
+	require.Equal(t, cluster.KubernetesVersion, config["kubernetes_version"])
+	require.Equal(t, cluster.Location, config["location"])
+
+	for k, v := range provider.CustomConfigurations {
+		require.Equal(t, v, config[k], fmt.Sprintf("Custom config %s is incorrect", k))
+	}
+
+	// named profile
+	provider.CustomConfigurations["profile"] = "ci"
+	config, err = a.loadConfigurations(cluster, provider)
+	require.NoError(t, err)
+	require.Equal(t, "CI-AKID", config["access_key_id"])
+	require.Equal(t, "CI-SECRET", config["secret_access_key"])
+	require.Equal(t, "CI-TOKEN", config["session_token"])
+
+	// unknown profile
+	provider.CustomConfigurations["profile"] = "unknown"
+	_, err = a.loadConfigurations(cluster, provider)
+	require.Error(t, err)
+	delete(provider.CustomConfigurations, "profile")
+
+	// credentials file not found
+	provider.CredentialsFilePath = "/wrong/credentials/path"
+	_, err = a.loadConfigurations(cluster, provider)
+	require.Error(t, err)
+}
+
+func fakeCredentials(file string) error {
+	fake := `[default]
+aws_access_key_id = AKID
+aws_secret_access_key = SECRET
+
+# profile used by the CI
+[ci]
+aws_access_key_id=CI-AKID
+aws_secret_access_key=CI-SECRET
+aws_session_token=CI-TOKEN
+`
+	//nolint:gosec
+	return os.WriteFile(file, []byte(fake), 0700)
+}
+
+func TestProvision(t *testing.T) {
+	t.Parallel()
+	mockOp := &mocks.Operator{}
+	a := AwsProvisioner{
+		provisionOperator: mockOp,
+	}
+
+	cluster := testCluster()
+	provider := testProvider("./credentials-provision")
+	err := fakeCredentials(provider.CredentialsFilePath)
+	require.NoError(t, err, "Creating a fake credentials file should not have an error")
+	defer os.Remove(provider.CredentialsFilePath)
+
+	result := &types.ClusterInfo{
+		CertificateAuthorityData: []byte("My cert"),
+		Endpoint:                 "https://cluster-url.fake",
+		Status: &types.ClusterStatus{
+			Phase: types.Provisioned,
+		},
+	}
+
+	cfg, err := a.loadConfigurations(cluster, provider)
+	require.NoError(t, err)
+
+	mockOp.On("Create", mock.Anything, types.AWS, cfg).Return(result, nil)
+
+	cluster, err = a.Provision(context.Background(), cluster, provider)
+	require.NoError(t, err, "Provision should succeed")
+	require.Equal(t, result, cluster.ClusterInfo, "The cluster info returned from the operator should be in the cluster returned by Provision")
+
+	badCluster := &types.Cluster{
+		CPU: 1,
+	}
+	_, err = a.Provision(context.Background(), badCluster, provider)
+	require.Error(t, err, "Provision should fail")
+}
+
+func TestDeprovision(t *testing.T) {
+	t.Parallel()
+	mockOp := &mocks.Operator{}
+	a := AwsProvisioner{
+		provisionOperator: mockOp,
+	}
+
+	cluster := testCluster()
+	provider := testProvider("./credentials-deprovision")
+
+	err := fakeCredentials(provider.CredentialsFilePath)
+	require.NoError(t, err, "Creating a fake credentials file should not have an error")
+	defer os.Remove(provider.CredentialsFilePath)
+
+	cfg, err := a.loadConfigurations(cluster, provider)
+	require.NoError(t, err)
+
+	mockOp.On("Delete", mock.Anything, cluster.ClusterInfo, types.AWS, cfg).Return(nil).Once()
+
+	err = a.Deprovision(context.Background(), cluster, provider)
+	require.NoError(t, err, "Deprovision should succeed")
+
+	mockOp.On("Delete", mock.Anything, cluster.ClusterInfo, types.AWS, cfg).Return(errors.New("Unable to deprovision cluster"))
+
+	err = a.Deprovision(context.Background(), cluster, provider)
+	require.Error(t, err, "Deprovision should fail")
+}
+
+func TestCredentials(t *testing.T) {
+	t.Parallel()
+	mockOp := &mocks.Operator{}
+	a := AwsProvisioner{
+		provisionOperator: mockOp,
+	}
+
+	cluster := testCluster()
+	provider := testProvider("./credentials-credentials")
+
+	err := fakeCredentials(provider.CredentialsFilePath)
+	require.NoError(t, err, "Creating a fake credentials file should not have an error")
+	defer os.Remove(provider.CredentialsFilePath)
+
+	cfg, err := a.loadConfigurations(cluster, provider)
+	require.NoError(t, err)
+
+	mockOp.On("Credentials", mock.Anything, cluster.ClusterInfo, types.AWS, cfg).Return([]byte("kubeconfig"), nil)
+
+	kubeconfig, err := a.Credentials(context.Background(), cluster, provider)
+	require.NoError(t, err, "Credentials should succeed")
+	require.Equal(t, []byte("kubeconfig"), kubeconfig)
+}
@@ -1,6 +1,7 @@
 package azure
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -9,8 +10,8 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/kyma-project/hydroform/provision/internal/errs"
-	"github.com/kyma-project/hydroform/provision/internal/operator"
-	"github.com/kyma-project/hydroform/provision/internal/operator/native"
+	"github.com/kyma-project/hydroform/provision/operator"
+	"github.com/kyma-project/hydroform/provision/provider"
 	"github.com/kyma-project/hydroform/provision/types"
 )
 
@@ -20,6 +21,12 @@ type AzureProvisioner struct {
 	provisionOperator operator.Operator
 }
 
+func init() {
+	provider.Register(types.Azure, func(operatorType operator.Type, ops ...types.Option) provider.Provisioner {
+		return New(operatorType, ops...)
+	})
+}
+
 // New creates a new instance of AzureProvisioner.
 func New(operatorType operator.Type, ops ...types.Option) *AzureProvisioner {
 	// parse config
@@ -28,13 +35,7 @@ func New(operatorType operator.Type, ops ...types.Option) *AzureProvisioner {
 		o(os)
 	}
 
-	var op operator.Operator
-	switch operatorType {
-	case operator.NativeOperator:
-		op = native.New(os)
-	default:
-		op = &operator.Unknown{}
-	}
+	op := operator.New(operatorType, os)
 
 	return &AzureProvisioner{
 		provisionOperator: op,
@@ -42,7 +43,7 @@ func New(operatorType operator.Type, ops ...types.Option) *AzureProvisioner {
 }
 
 // Provision requests provisioning of a new Kubernetes cluster on Azure with the given configurations.
-func (a *AzureProvisioner) Provision(cluster *types.Cluster, provider *types.Provider) (*types.Cluster, error) {
+func (a *AzureProvisioner) Provision(ctx context.Context, cluster *types.Cluster, provider *types.Provider) (*types.Cluster, error) {
 	if err := a.validateInputs(cluster, provider); err != nil {
 		return cluster, err
 	}
@@ -52,7 +53,7 @@ func (a *AzureProvisioner) Provision(cluster *types.Cluster, provider *types.Pro
 		return cluster, err
 	}
 
-	clusterInfo, err := a.provisionOperator.Create(provider.Type, config)
+	clusterInfo, err := a.provisionOperator.Create(ctx, provider.Type, config)
 	if err != nil {
 		return cluster, errors.Wrap(err, "unable to provision azure cluster")
 	}
@@ -62,7 +63,7 @@ func (a *AzureProvisioner) Provision(cluster *types.Cluster, provider *types.Pro
 }
 
 // Status returns the ClusterStatus for the requested cluster.
-func (a *AzureProvisioner) Status(cluster *types.Cluster, p *types.Provider) (*types.ClusterStatus, error) {
+func (a *AzureProvisioner) Status(ctx context.Context, cluster *types.Cluster, p *types.Provider) (*types.ClusterStatus, error) {
 	if err := a.validateInputs(cluster, p); err != nil {
 		return nil, err
 	}
@@ -72,16 +73,50 @@ func (a *AzureProvisioner) Status(cluster *types.Cluster, p *types.Provider) (*t
 		return nil, err
 	}
 
-	return a.provisionOperator.Status(cluster.ClusterInfo, p.Type, cfg)
+	return a.provisionOperator.Status(ctx, cluster.ClusterInfo, p.Type, cfg)
 }
 
 // Credentials returns the Kubeconfig file as a byte array for the requested cluster.
-func (a *AzureProvisioner) Credentials(cluster *types.Cluster, p *types.Provider) ([]byte, error) {
-	return nil, errors.New("Not supported")
+func (a *AzureProvisioner) Credentials(ctx context.Context, cluster *types.Cluster, p *types.Provider) ([]byte, error) {
+	if err := a.validateInputs(cluster, p); err != nil {
+		return nil, err
+	}
+
+	config, err := a.loadConfigurations(cluster, p)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeconfig, err := a.provisionOperator.Credentials(ctx, cluster.ClusterInfo, p.Type, config)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to fetch azure cluster credentials")
+	}
+
+	return kubeconfig, nil
+}
+
+// Update requests changing an existing cluster on Azure to match the given configurations.
+func (a *AzureProvisioner) Update(ctx context.Context, cluster *types.Cluster, p *types.Provider) (*types.Cluster, error) {
+	if err := a.validateInputs(cluster, p); err != nil {
+		return cluster, err
+	}
+
+	config, err := a.loadConfigurations(cluster, p)
+	if err != nil {
+		return cluster, err
+	}
+
+	clusterInfo, err := a.provisionOperator.Update(ctx, cluster.ClusterInfo, p.Type, config)
+	if err != nil {
+		return cluster, errors.Wrap(err, "unable to update azure cluster")
+	}
+
+	cluster.ClusterInfo = clusterInfo
+	return cluster, nil
 }
 
 // Deprovision requests deprovisioning of an existing cluster on Azure with the given configurations.
-func (a *AzureProvisioner) Deprovision(cluster *types.Cluster, p *types.Provider) error {
+func (a *AzureProvisioner) Deprovision(ctx context.Context, cluster *types.Cluster, p *types.Provider) error {
 	if err := a.validateInputs(cluster, p); err != nil {
 		return err
 	}
@@ -91,7 +126,7 @@ func (a *AzureProvisioner) Deprovision(cluster *types.Cluster, p *types.Provider
 		return err
 	}
 
-	if err = a.provisionOperator.Delete(cluster.ClusterInfo, p.Type, config); err != nil {
+	if err = a.provisionOperator.Delete(ctx, cluster.ClusterInfo, p.Type, config); err != nil {
 		return errors.Wrap(err, "unable to deprovision azure cluster")
 	}
 
@@ -125,6 +160,10 @@ func (a *AzureProvisioner) validateInputs(cluster *types.Cluster, provider *type
 	if provider.CredentialsFilePath == "" {
 		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.CredentialsFilePath")
 	}
+	// the credentials are validated once they are merged with the credentials file
+	if _, err := provider.Config(); err != nil {
+		errMessage += errs.Config(err)
+	}
 
 	if errMessage != "" {
 		return errors.New("input validation failed with the following information: " + errMessage)
@@ -145,13 +184,41 @@ func (a *AzureProvisioner) loadConfigurations(cluster *types.Cluster, provider *
 	config["project"] = provider.ProjectName
 	config["resource_group"] = provider.ProjectName
 
-	var err error
-	config["subscription_id"], config["tenant_id"], config["client_id"], config["client_secret"], err = azureCredentials(provider.CredentialsFilePath)
+	custom, err := provider.Configurations()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := provider.Config()
+	if err != nil {
+		return nil, err
+	}
+	azureCfg := cfg.(*types.AzureConfig)
+
+	creds, err := azureCredentials(provider.CredentialsFilePath)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error loading credentials")
 	}
+	// the credentials of the configuration take precedence over the credentials file
+	if azureCfg.SubscriptionID == "" {
+		azureCfg.SubscriptionID = creds.SubscriptionID
+	}
+	if azureCfg.TenantID == "" {
+		azureCfg.TenantID = creds.TenantID
+	}
+	if azureCfg.ClientID == "" {
+		azureCfg.ClientID = creds.ClientID
+	}
+	if azureCfg.ClientSecret == "" {
+		azureCfg.ClientSecret = creds.ClientSecret
+	}
+	if err := azureCfg.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "missing Azure credentials in the configuration and in %s", provider.CredentialsFilePath)
+	}
 
-	for k, v := range provider.CustomConfigurations {
+	for k, v := range custom {
+		config[k] = v
+	}
+	for k, v := range azureCfg.Map() {
 		config[k] = v
 	}
 
@@ -160,10 +227,10 @@ func (a *AzureProvisioner) loadConfigurations(cluster *types.Cluster, provider *
 
 // azureCredentials extracts the values of a credentials file to authenticate on azure.
 // It expects a JSON file containing the subscription ID, tenant ID, client ID and client secret.
-func azureCredentials(path string) (subscriptionID, tenantID, clientID, clientSecret string, err error) {
+func azureCredentials(path string) (*types.AzureConfig, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return
+		return nil, err
 	}
 
 	c := struct {
@@ -174,13 +241,13 @@ func azureCredentials(path string) (subscriptionID, tenantID, clientID, clientSe
 	}{}
 
 	if err = json.Unmarshal(data, &c); err != nil {
-		return
+		return nil, err
 	}
 
-	subscriptionID = c.SubscriptionID
-	tenantID = c.TenantID
-	clientID = c.ClientID
-	clientSecret = c.Secret
-
-	return
+	return &types.AzureConfig{
+		SubscriptionID: c.SubscriptionID,
+		TenantID:       c.TenantID,
+		ClientID:       c.ClientID,
+		ClientSecret:   c.Secret,
+	}, nil
 }
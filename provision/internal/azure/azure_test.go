@@ -1,6 +1,7 @@
 package azure
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
@@ -9,6 +10,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/kyma-project/hydroform/provision/types"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -138,6 +140,20 @@ func TestLoadConfigurations(t *testing.T) {
 		require.Equal(t, v, config[k], fmt.Sprintf("Custom config %s is incorrect", k))
 	}
 
+	// typed credentials take precedence over the credentials file
+	provider.Azure = &types.AzureConfig{ClientID: "typed-client-id", ResourceGroup: "typed-group"}
+	config, err = g.loadConfigurations(cluster, provider)
+	require.NoError(t, err)
+	require.Equal(t, "typed-client-id", config["client_id"])
+	require.Equal(t, "fake-client-secret", config["client_secret"])
+	require.Equal(t, "typed-group", config["resource_group"])
+	provider.Azure = nil
+
+	// credentials missing in both the configuration and the credentials file
+	require.NoError(t, os.WriteFile(provider.CredentialsFilePath, []byte(`{"subscription_id": "fake-subscription-id"}`), 0600))
+	_, err = g.loadConfigurations(cluster, provider)
+	require.ErrorContains(t, err, "Provider.CustomConfigurations['client_secret'] cannot be empty")
+
 	// credentials file not found
 	provider.CredentialsFilePath = "/wrong/credentials/path"
 	_, err = g.loadConfigurations(cluster, provider)
@@ -197,9 +213,9 @@ func TestProvision(t *testing.T) {
 	cfg, err := g.loadConfigurations(cluster, provider)
 	require.NoError(t, err)
 
-	mockOp.On("Create", types.Azure, cfg).Return(result, nil)
+	mockOp.On("Create", mock.Anything, types.Azure, cfg).Return(result, nil)
 
-	cluster, err = g.Provision(cluster, provider)
+	cluster, err = g.Provision(context.Background(), cluster, provider)
 	require.NoError(t, err, "Provision should succeed")
 	require.Equal(t, result, cluster.ClusterInfo, "The cluster info returned from the operator should be in the cluster returned by Provision")
 
@@ -209,9 +225,9 @@ func TestProvision(t *testing.T) {
 
 	cfg, err = g.loadConfigurations(badCluster, provider)
 	require.NoError(t, err)
-	mockOp.On("Create", types.Azure, cfg).Return(badCluster, errors.New("Unable to provision cluster"))
+	mockOp.On("Create", mock.Anything, types.Azure, cfg).Return(badCluster, errors.New("Unable to provision cluster"))
 
-	_, err = g.Provision(badCluster, provider)
+	_, err = g.Provision(context.Background(), badCluster, provider)
 	require.Error(t, err, "Provision should fail")
 }
 
@@ -251,17 +267,54 @@ func TestDeprovision(t *testing.T) {
 	cfg, err := g.loadConfigurations(cluster, provider)
 	require.NoError(t, err)
 
-	mockOp.On("Delete", cluster.ClusterInfo, types.Azure, cfg).Return(nil)
+	mockOp.On("Delete", mock.Anything, cluster.ClusterInfo, types.Azure, cfg).Return(nil)
 
-	err = g.Deprovision(cluster, provider)
+	err = g.Deprovision(context.Background(), cluster, provider)
 	require.NoError(t, err, "Deprovision should succeed")
 
 	provider.ProjectName = "invalid-resource-group"
 	cfg, err = g.loadConfigurations(cluster, provider)
 	require.NoError(t, err)
 
-	mockOp.On("Delete", cluster.ClusterInfo, types.Azure, cfg).Return(errors.New("Unable to deprovision cluster"))
+	mockOp.On("Delete", mock.Anything, cluster.ClusterInfo, types.Azure, cfg).Return(errors.New("Unable to deprovision cluster"))
 
-	err = g.Deprovision(cluster, provider)
+	err = g.Deprovision(context.Background(), cluster, provider)
 	require.Error(t, err, "Deprovision should fail")
 }
+
+func TestCredentials(t *testing.T) {
+	t.Parallel()
+	mockOp := &mocks.Operator{}
+	g := AzureProvisioner{
+		provisionOperator: mockOp,
+	}
+
+	cluster := &types.Cluster{
+		CPU:               1,
+		KubernetesVersion: "1.12",
+		Name:              "hydro-cluster",
+		DiskSizeGB:        30,
+		NodeCount:         2,
+		Location:          "europe-west3",
+		MachineType:       "type1",
+		ClusterInfo:       &types.ClusterInfo{},
+	}
+	provider := &types.Provider{
+		Type:                types.Azure,
+		ProjectName:         "my-resource-group",
+		CredentialsFilePath: "./credentials-credentials.json",
+	}
+
+	err := fakeCredentials(provider.CredentialsFilePath)
+	require.NoError(t, err, "Creating a fake credentials file should not have an error")
+	defer os.Remove(provider.CredentialsFilePath)
+
+	cfg, err := g.loadConfigurations(cluster, provider)
+	require.NoError(t, err)
+
+	mockOp.On("Credentials", mock.Anything, cluster.ClusterInfo, types.Azure, cfg).Return([]byte("kubeconfig"), nil)
+
+	kubeconfig, err := g.Credentials(context.Background(), cluster, provider)
+	require.NoError(t, err, "Credentials should succeed")
+	require.Equal(t, []byte("kubeconfig"), kubeconfig)
+}
@@ -0,0 +1,27 @@
+package errs
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+// Config formats the error of a typed provider configuration as validation messages, one per invalid field.
+func Config(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var vErr *types.ValidationError
+	if !errors.As(err, &vErr) {
+		return fmt.Sprintf(Custom, err)
+	}
+
+	var msg string
+	for _, m := range vErr.Messages {
+		msg += fmt.Sprintf(Custom, m)
+	}
+	return msg
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"k8s.io/client-go/tools/clientcmd"
 
@@ -16,8 +17,9 @@ import (
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/kyma-project/hydroform/provision/internal/errs"
-	"github.com/kyma-project/hydroform/provision/internal/operator"
-	"github.com/kyma-project/hydroform/provision/internal/operator/native"
+	nativeGardener "github.com/kyma-project/hydroform/provision/internal/operator/native/gardener"
+	"github.com/kyma-project/hydroform/provision/operator"
+	"github.com/kyma-project/hydroform/provision/provider"
 	"github.com/kyma-project/hydroform/provision/types"
 )
 
@@ -30,6 +32,13 @@ const (
 //nolint:revive
 type GardenerProvisioner struct {
 	operator operator.Operator
+	ops      *types.Options
+}
+
+func init() {
+	provider.Register(types.Gardener, func(operatorType operator.Type, ops ...types.Option) provider.Provisioner {
+		return New(operatorType, ops...)
+	})
 }
 
 func New(operatorType operator.Type, ops ...types.Option) *GardenerProvisioner {
@@ -39,26 +48,21 @@ func New(operatorType operator.Type, ops ...types.Option) *GardenerProvisioner {
 		o(os)
 	}
 
-	var op operator.Operator
-	switch operatorType {
-	case operator.NativeOperator:
-		op = native.New(os)
-	default:
-		op = &operator.Unknown{}
-	}
+	op := operator.New(operatorType, os)
 	return &GardenerProvisioner{
 		operator: op,
+		ops:      os,
 	}
 }
 
-func (g *GardenerProvisioner) Provision(cluster *types.Cluster, provider *types.Provider) (*types.Cluster, error) {
+func (g *GardenerProvisioner) Provision(ctx context.Context, cluster *types.Cluster, provider *types.Provider) (*types.Cluster, error) {
 	if err := g.validate(cluster, provider); err != nil {
 		return cluster, err
 	}
 
 	config := g.loadConfigurations(cluster, provider)
 
-	clusterInfo, err := g.operator.Create(provider.Type, config)
+	clusterInfo, err := g.operator.Create(ctx, provider.Type, config)
 	if err != nil {
 		return cluster, errors.Wrap(err, "unable to provision gardener cluster")
 	}
@@ -67,17 +71,21 @@ func (g *GardenerProvisioner) Provision(cluster *types.Cluster, provider *types.
 }
 
 // Status returns the ClusterStatus for the requested cluster.
-func (g *GardenerProvisioner) Status(cluster *types.Cluster, p *types.Provider) (*types.ClusterStatus, error) {
+func (g *GardenerProvisioner) Status(ctx context.Context, cluster *types.Cluster, p *types.Provider) (*types.ClusterStatus, error) {
 	if err := g.validate(cluster, p); err != nil {
 		return nil, err
 	}
 
 	cfg := g.loadConfigurations(cluster, p)
 
-	return g.operator.Status(cluster.ClusterInfo, p.Type, cfg)
+	// the operator refreshes the endpoint, CA and shoot identifiers in the cluster info
+	if cluster.ClusterInfo == nil {
+		cluster.ClusterInfo = &types.ClusterInfo{}
+	}
+	return g.operator.Status(ctx, cluster.ClusterInfo, p.Type, cfg)
 }
 
-func (g *GardenerProvisioner) Credentials(cluster *types.Cluster, provider *types.Provider) ([]byte, error) {
+func (g *GardenerProvisioner) Credentials(ctx context.Context, cluster *types.Cluster, provider *types.Provider) ([]byte, error) {
 	if err := g.validate(cluster, provider); err != nil {
 		return nil, err
 	}
@@ -92,11 +100,11 @@ func (g *GardenerProvisioner) Credentials(cluster *types.Cluster, provider *type
 		return nil, err
 	}
 
-	adminKubeConfig, err := fetchAdminKubeConfigSubResource(k8s, provider.ProjectName, cluster.Name)
+	adminKubeConfig, err := fetchAdminKubeConfigSubResource(ctx, k8s, provider.ProjectName, cluster.Name)
 
 	// TODO: Remove the get kubeconfig secret when Gardener drops its support from Kubernetes v1.27
 	if err != nil || adminKubeConfig == nil {
-		s, err := k8s.CoreV1().Secrets(fmt.Sprintf("garden-%s", provider.ProjectName)).Get(context.Background(),
+		s, err := k8s.CoreV1().Secrets(fmt.Sprintf("garden-%s", provider.ProjectName)).Get(ctx,
 			fmt.Sprintf("%s.kubeconfig", cluster.Name), metav1.GetOptions{})
 		if err == nil {
 			return s.Data["kubeconfig"], nil
@@ -108,14 +116,66 @@ func (g *GardenerProvisioner) Credentials(cluster *types.Cluster, provider *type
 	return adminKubeConfig, nil
 }
 
-func (g *GardenerProvisioner) Deprovision(cluster *types.Cluster, p *types.Provider) error {
+// Update requests changing an existing cluster on Gardener to match the given configurations.
+// Worker pools that are no longer part of the cluster are removed from the shoot.
+func (g *GardenerProvisioner) Update(ctx context.Context, cluster *types.Cluster, p *types.Provider) (*types.Cluster, error) {
+	if err := g.validate(cluster, p); err != nil {
+		return cluster, err
+	}
+
+	config := g.loadConfigurations(cluster, p)
+
+	clusterInfo, err := g.operator.Update(ctx, cluster.ClusterInfo, p.Type, config)
+	if err != nil {
+		return cluster, errors.Wrap(err, "unable to update gardener cluster")
+	}
+
+	cluster.ClusterInfo = clusterInfo
+	return cluster, nil
+}
+
+// Hibernate requests hibernating an existing Gardener cluster.
+func (g *GardenerProvisioner) Hibernate(ctx context.Context, cluster *types.Cluster, p *types.Provider) (*types.Cluster, error) {
+	if err := g.validate(cluster, p); err != nil {
+		return cluster, err
+	}
+
+	config := g.loadConfigurations(cluster, p)
+
+	clusterInfo, err := g.operator.Hibernate(ctx, cluster.ClusterInfo, p.Type, config)
+	if err != nil {
+		return cluster, errors.Wrap(err, "unable to hibernate gardener cluster")
+	}
+
+	cluster.ClusterInfo = clusterInfo
+	return cluster, nil
+}
+
+// WakeUp requests waking up a hibernated Gardener cluster.
+func (g *GardenerProvisioner) WakeUp(ctx context.Context, cluster *types.Cluster, p *types.Provider) (*types.Cluster, error) {
+	if err := g.validate(cluster, p); err != nil {
+		return cluster, err
+	}
+
+	config := g.loadConfigurations(cluster, p)
+
+	clusterInfo, err := g.operator.WakeUp(ctx, cluster.ClusterInfo, p.Type, config)
+	if err != nil {
+		return cluster, errors.Wrap(err, "unable to wake up gardener cluster")
+	}
+
+	cluster.ClusterInfo = clusterInfo
+	return cluster, nil
+}
+
+func (g *GardenerProvisioner) Deprovision(ctx context.Context, cluster *types.Cluster, p *types.Provider) error {
 	if err := g.validate(cluster, p); err != nil {
 		return err
 	}
 
 	config := g.loadConfigurations(cluster, p)
 
-	err := g.operator.Delete(cluster.ClusterInfo, p.Type, config)
+	err := g.operator.Delete(ctx, cluster.ClusterInfo, p.Type, config)
 	if err != nil {
 		return errors.Wrap(err, "unable to deprovision gardener cluster")
 	}
@@ -123,7 +183,122 @@ func (g *GardenerProvisioner) Deprovision(cluster *types.Cluster, p *types.Provi
 	return nil
 }
 
+// Plan renders the shoot that Provision would create, without contacting Gardener.
+// The credentials are not needed to render the shoot.
+func (g *GardenerProvisioner) Plan(cluster *types.Cluster, p *types.Provider) (*types.Plan, error) {
+	if err := g.validateSpec(cluster, p, false); err != nil {
+		return nil, err
+	}
+
+	shoot, err := nativeGardener.Render(g.loadConfigurations(cluster, p))
+	if err != nil {
+		return nil, err
+	}
+	return &types.Plan{Manifest: shoot}, nil
+}
+
+// Diff compares the shoot that Provision would create with the live shoot on Gardener.
+func (g *GardenerProvisioner) Diff(ctx context.Context, cluster *types.Cluster, p *types.Provider) (*types.PlanDiff, error) {
+	if err := g.validate(cluster, p); err != nil {
+		return nil, err
+	}
+
+	diff, err := nativeGardener.Diff(ctx, g.ops, g.loadConfigurations(cluster, p))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to compare gardener cluster")
+	}
+	return diff, nil
+}
+
+// Import reads the shoot with the name of the cluster from the project of the provider.
+// It returns the cluster and the provider with the Gardener configuration that describe the shoot.
+func (g *GardenerProvisioner) Import(ctx context.Context, cluster *types.Cluster, p *types.Provider) (*types.Cluster, *types.Provider, error) {
+	var errMessage string
+	if cluster.Name == "" {
+		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Cluster.Name")
+	}
+	if p.CredentialsFilePath == "" {
+		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.CredentialsFilePath")
+	}
+	if p.ProjectName == "" {
+		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.ProjectName")
+	}
+	if errMessage != "" {
+		return nil, nil, errors.New("input validation failed with the following information: " + errMessage)
+	}
+
+	config := map[string]interface{}{
+		"cluster_name":          cluster.Name,
+		"credentials_file_path": p.CredentialsFilePath,
+		"namespace":             fmt.Sprintf("garden-%s", p.ProjectName),
+	}
+	imported, cfg, err := nativeGardener.Import(ctx, g.ops, config)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to import gardener cluster")
+	}
+	return imported, importedProvider(p.ProjectName, p, cfg), nil
+}
+
+// ImportManifest reads a shoot manifest in YAML or JSON.
+// The project is taken from the namespace of the shoot, and the credentials from the given provider, which can be nil.
+func (g *GardenerProvisioner) ImportManifest(manifest []byte, p *types.Provider) (*types.Cluster, *types.Provider, error) {
+	shoot, err := nativeGardener.DecodeShoot(manifest)
+	if err != nil {
+		return nil, nil, err
+	}
+	imported, cfg, err := nativeGardener.FromShoot(shoot)
+	if err != nil {
+		return nil, nil, err
+	}
+	return imported, importedProvider(strings.TrimPrefix(shoot.Namespace, "garden-"), p, cfg), nil
+}
+
+// List returns the shoots in the project of the provider that match the filter.
+func (g *GardenerProvisioner) List(ctx context.Context, p *types.Provider, filter types.ListFilter) ([]types.Cluster, error) {
+	var errMessage string
+	if p.CredentialsFilePath == "" {
+		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.CredentialsFilePath")
+	}
+	if p.ProjectName == "" {
+		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.ProjectName")
+	}
+	if errMessage != "" {
+		return nil, errors.New("input validation failed with the following information: " + errMessage)
+	}
+
+	config := map[string]interface{}{
+		"credentials_file_path": p.CredentialsFilePath,
+		"namespace":             fmt.Sprintf("garden-%s", p.ProjectName),
+	}
+	clusters, err := nativeGardener.List(ctx, g.ops, config, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list gardener clusters")
+	}
+	return clusters, nil
+}
+
+// importedProvider returns the provider of an imported shoot. The credentials and a missing project are taken from the given provider.
+func importedProvider(project string, p *types.Provider, cfg *types.GardenerConfig) *types.Provider {
+	imported := &types.Provider{
+		Type:        types.Gardener,
+		ProjectName: project,
+		Gardener:    cfg,
+	}
+	if p != nil {
+		imported.CredentialsFilePath = p.CredentialsFilePath
+		if imported.ProjectName == "" {
+			imported.ProjectName = p.ProjectName
+		}
+	}
+	return imported
+}
+
 func (g *GardenerProvisioner) validate(cluster *types.Cluster, provider *types.Provider) error {
+	return g.validateSpec(cluster, provider, true)
+}
+
+// validateSpec validates the cluster and the provider. The credentials are only required if withCredentials is true.
+func (g *GardenerProvisioner) validateSpec(cluster *types.Cluster, provider *types.Provider, withCredentials bool) error {
 	var errMessage string
 
 	// Cluster
@@ -150,7 +325,7 @@ func (g *GardenerProvisioner) validate(cluster *types.Cluster, provider *types.P
 	}
 
 	// Provider
-	if provider.CredentialsFilePath == "" {
+	if withCredentials && provider.CredentialsFilePath == "" {
 		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.CredentialsFilePath")
 	}
 	if provider.ProjectName == "" {
@@ -158,60 +333,24 @@ func (g *GardenerProvisioner) validate(cluster *types.Cluster, provider *types.P
 	}
 
 	// Custom gardener configuration
-	targetProvider, ok := provider.CustomConfigurations["target_provider"]
-	if ok {
-		if targetProvider != string(types.GCP) && targetProvider != string(types.AWS) && targetProvider != string(types.Azure) {
-			errMessage += fmt.Sprintf(errs.Custom,
-				"Provider.CustomConfigurations['target_provider'] has to be one of: gcp, azure, aws")
-		}
+	cfg, err := provider.Config()
+	if err != nil {
+		errMessage += errs.Config(err)
 	} else {
-		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.CustomConfigurations['target_provider']")
-	}
-	if _, ok := provider.CustomConfigurations["target_secret"]; !ok {
-		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.CustomConfigurations['target_secret']")
-	}
-
-	if _, ok := provider.CustomConfigurations["disk_type"]; !ok {
-		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.CustomConfigurations['disk_type']")
-	}
-	if _, ok := provider.CustomConfigurations["worker_minimum"]; !ok {
-		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.CustomConfigurations['worker_minimum']")
-	}
-	if _, ok := provider.CustomConfigurations["worker_maximum"]; !ok {
-		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.CustomConfigurations['worker_maximum']")
-	}
-	if _, ok := provider.CustomConfigurations["worker_max_surge"]; !ok {
-		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.CustomConfigurations['worker_max_surge']")
-	}
-	if _, ok := provider.CustomConfigurations["worker_max_unavailable"]; !ok {
-		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.CustomConfigurations['worker_max_unavailable']")
-	}
-	if _, ok := provider.CustomConfigurations["workercidr"]; !ok && (targetProvider == string(types.GCP) || targetProvider == string(types.Azure)) {
-		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.CustomConfigurations['workercidr']")
-	}
-	if _, ok := provider.CustomConfigurations["zones"]; !ok && (targetProvider == string(types.GCP) || targetProvider == string(types.AWS)) {
-		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.CustomConfigurations['zone']")
-	}
-	if _, ok := provider.CustomConfigurations["vnetcidr"]; !ok && (targetProvider == string(types.Azure) || targetProvider == string(types.AWS)) {
-		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.CustomConfigurations['vnetcidr']")
-	}
-
-	if _, ok := provider.CustomConfigurations["machine_image_name"]; !ok && targetProvider == string(types.Azure) {
-		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.CustomConfigurations['machine_image_name']")
-	}
-	if _, ok := provider.CustomConfigurations["machine_image_version"]; !ok && targetProvider == string(types.Azure) {
-		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.CustomConfigurations['machine_image_version']")
-	}
-
-	if _, ok := provider.CustomConfigurations["networking_type"]; !ok {
-		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.CustomConfigurations['networking_type']")
-	}
-	if _, ok := provider.CustomConfigurations["service_endpoints"]; !ok && targetProvider == string(types.Azure) {
-		provider.CustomConfigurations["service_endpoints"] = []string{""}
-	}
-	if _, ok := provider.CustomConfigurations["gcp_control_plane_zone"]; !ok && targetProvider == string(types.GCP) {
-		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.CustomConfigurations['gcp_control_plane_zone']")
+		errMessage += errs.Config(cfg.Validate())
+
+		// the single-pool scaling is only needed if no worker pools are given
+		gardenerCfg := cfg.(*types.GardenerConfig)
+		if len(cluster.WorkerPools) == 0 {
+			if gardenerCfg.WorkerMinimum == nil {
+				errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.CustomConfigurations['worker_minimum']")
+			}
+			if gardenerCfg.WorkerMaximum == nil {
+				errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.CustomConfigurations['worker_maximum']")
+			}
+		}
 	}
+	errMessage += validateWorkerPools(cluster.WorkerPools)
 
 	if errMessage != "" {
 		return errors.New("input validation failed with the following information: " + errMessage)
@@ -219,6 +358,45 @@ func (g *GardenerProvisioner) validate(cluster *types.Cluster, provider *types.P
 	return nil
 }
 
+// validateWorkerPools returns the validation messages for the given worker pools.
+func validateWorkerPools(pools []types.WorkerPool) string {
+	var errMessage string
+	names := map[string]bool{}
+	for i, pool := range pools {
+		field := fmt.Sprintf("Cluster.WorkerPools[%d]", i)
+		// Matches the regex for a Gardener worker name.
+		if match, err := regexp.MatchString(`^[a-z0-9](?:[-a-z0-9]{0,13}[a-z0-9])?$`, pool.Name); !match || err != nil {
+			errMessage += fmt.Sprintf(errs.Custom,
+				field+".Name must consist of up to 15 lowercase letters, numbers, or hyphens, and cannot start or end with a hyphen")
+		}
+		if names[pool.Name] {
+			errMessage += fmt.Sprintf(errs.Custom, field+".Name must be unique")
+		}
+		names[pool.Name] = true
+
+		if pool.Minimum < 0 {
+			errMessage += fmt.Sprintf(errs.CannotBeLess, field+".Minimum", 0)
+		}
+		if pool.Maximum < 1 {
+			errMessage += fmt.Sprintf(errs.CannotBeLess, field+".Maximum", 1)
+		} else if pool.Maximum < pool.Minimum {
+			errMessage += fmt.Sprintf(errs.Custom, field+".Maximum cannot be less than the minimum")
+		}
+		for j, t := range pool.Taints {
+			if t.Key == "" {
+				errMessage += fmt.Sprintf(errs.CannotBeEmpty, fmt.Sprintf("%s.Taints[%d].Key", field, j))
+			}
+			switch t.Effect {
+			case types.TaintEffectNoSchedule, types.TaintEffectPreferNoSchedule, types.TaintEffectNoExecute:
+			default:
+				errMessage += fmt.Sprintf(errs.Custom,
+					fmt.Sprintf("%s.Taints[%d].Effect has to be one of: NoSchedule, PreferNoSchedule, NoExecute", field, j))
+			}
+		}
+	}
+	return errMessage
+}
+
 func (*GardenerProvisioner) loadConfigurations(cluster *types.Cluster,
 	provider *types.Provider) map[string]interface{} {
 	config := map[string]interface{}{}
@@ -231,8 +409,16 @@ func (*GardenerProvisioner) loadConfigurations(cluster *types.Cluster,
 	config["location"] = cluster.Location
 	config["project"] = provider.ProjectName
 	config["namespace"] = fmt.Sprintf("garden-%s", provider.ProjectName)
+	if len(cluster.WorkerPools) > 0 {
+		config["worker_pools"] = cluster.WorkerPools
+	}
 
-	for k, v := range provider.CustomConfigurations {
+	custom, err := provider.Configurations()
+	if err != nil {
+		// invalid values are reported by validate
+		custom = provider.CustomConfigurations
+	}
+	for k, v := range custom {
 		config[k] = v
 	}
 
@@ -260,12 +446,13 @@ func (*GardenerProvisioner) loadConfigurations(cluster *types.Cluster,
 		}
 
 		// need to set the zoned property if we have a cluster with zones
-		config["zoned"] = strconv.FormatBool(len(config["zones"].([]string)) > 0) // add zoned boolean
+		zones, _ := config["zones"].([]string)
+		config["zoned"] = strconv.FormatBool(len(zones) > 0) // add zoned boolean
 	}
 	return config
 }
 
-func fetchAdminKubeConfigSubResource(k8s *kubernetes.Clientset, projectName string, clusterName string) ([]byte,
+func fetchAdminKubeConfigSubResource(ctx context.Context, k8s *kubernetes.Clientset, projectName string, clusterName string) ([]byte,
 	error) {
 	uri := fmt.Sprintf("/apis/core.gardener.cloud/v1beta1/namespaces/garden-%s/shoots/%s/adminkubeconfig", projectName,
 		clusterName)
@@ -278,7 +465,7 @@ func fetchAdminKubeConfigSubResource(k8s *kubernetes.Clientset, projectName stri
 	}`)
 
 	request := k8s.RESTClient().Post().RequestURI(uri).Body(kubeConfigRequestBody)
-	stream, err := request.Stream(context.TODO())
+	stream, err := request.Stream(ctx)
 	if err != nil {
 		return nil, err
 	}
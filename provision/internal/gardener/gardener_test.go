@@ -1,6 +1,7 @@
 package gardener
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/kyma-project/hydroform/provision/types"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -284,6 +286,35 @@ func TestLoadConfigurations(t *testing.T) {
 	}
 }
 
+func TestLoadConfigurationsDecoded(t *testing.T) {
+	t.Parallel()
+	g := GardenerProvisioner{}
+
+	cluster := &types.Cluster{Name: "hydro-cluster"}
+	// values as decoded from JSON or YAML
+	provider := &types.Provider{
+		Type:        types.Gardener,
+		ProjectName: "my-project",
+		CustomConfigurations: map[string]interface{}{
+			"target_provider": "azure",
+			"zones":           []interface{}{"1", "2"},
+			"worker_minimum":  float64(1),
+			"vnetcidr":        "10.250.0.0/16",
+		},
+	}
+
+	config := g.loadConfigurations(cluster, provider)
+	require.Equal(t, []string{"1", "2"}, config["zones"])
+	require.Equal(t, 1, config["worker_minimum"])
+	require.Equal(t, "true", config["zoned"])
+	require.Equal(t, "10.250.0.0/16", config["networking_nodes"])
+
+	// azure clusters without zones
+	delete(provider.CustomConfigurations, "zones")
+	config = g.loadConfigurations(cluster, provider)
+	require.Equal(t, "false", config["zoned"])
+}
+
 func TestProvision(t *testing.T) {
 	t.Parallel()
 	mockOp := &mocks.Operator{}
@@ -326,18 +357,18 @@ func TestProvision(t *testing.T) {
 			Phase: types.Provisioned,
 		},
 	}
-	mockOp.On("Create", types.Gardener, g.loadConfigurations(cluster, provider)).Return(result, nil)
+	mockOp.On("Create", mock.Anything, types.Gardener, g.loadConfigurations(cluster, provider)).Return(result, nil)
 
-	cluster, err := g.Provision(cluster, provider)
+	cluster, err := g.Provision(context.Background(), cluster, provider)
 	require.NoError(t, err, "Provision should succeed")
 	require.Equal(t, result, cluster.ClusterInfo, "The cluster info returned from the operator should be in the cluster returned by Provision")
 
 	badCluster := &types.Cluster{
 		CPU: 1,
 	}
-	mockOp.On("Create", types.Gardener, g.loadConfigurations(badCluster, provider)).Return(badCluster, errors.New("Unable to provision cluster"))
+	mockOp.On("Create", mock.Anything, types.Gardener, g.loadConfigurations(badCluster, provider)).Return(badCluster, errors.New("Unable to provision cluster"))
 
-	_, err = g.Provision(badCluster, provider)
+	_, err = g.Provision(context.Background(), badCluster, provider)
 	require.Error(t, err, "Provision should fail")
 }
 
@@ -376,14 +407,283 @@ func TestDeProvision(t *testing.T) {
 			"networking_type":        "calico",
 		},
 	}
-	mockOp.On("Delete", cluster.ClusterInfo, types.Gardener, g.loadConfigurations(cluster, provider)).Return(nil)
+	mockOp.On("Delete", mock.Anything, cluster.ClusterInfo, types.Gardener, g.loadConfigurations(cluster, provider)).Return(nil)
 
-	err := g.Deprovision(cluster, provider)
+	err := g.Deprovision(context.Background(), cluster, provider)
 	require.NoError(t, err, "Deprovision should succeed")
 
 	provider.CredentialsFilePath = "/wrong/credentials"
-	mockOp.On("Delete", cluster.ClusterInfo, types.Gardener, g.loadConfigurations(cluster, provider)).Return(errors.New("Unable to deprovision cluster"))
+	mockOp.On("Delete", mock.Anything, cluster.ClusterInfo, types.Gardener, g.loadConfigurations(cluster, provider)).Return(errors.New("Unable to deprovision cluster"))
 
-	err = g.Deprovision(cluster, provider)
+	err = g.Deprovision(context.Background(), cluster, provider)
 	require.Error(t, err, "Deprovision should fail")
 }
+
+func TestUpdate(t *testing.T) {
+	t.Parallel()
+	mockOp := &mocks.Operator{}
+	g := GardenerProvisioner{
+		operator: mockOp,
+	}
+
+	cluster := &types.Cluster{
+		CPU:               1,
+		KubernetesVersion: "1.27",
+		Name:              "hydro-cluster",
+		DiskSizeGB:        30,
+		NodeCount:         2,
+		Location:          "europe-west3",
+		MachineType:       "type1",
+		ClusterInfo:       &types.ClusterInfo{},
+	}
+	provider := &types.Provider{
+		Type:                types.Gardener,
+		ProjectName:         "my-project",
+		CredentialsFilePath: "/path/to/credentials",
+		CustomConfigurations: map[string]interface{}{
+			"target_provider":        "gcp",
+			"target_secret":          "secret-name",
+			"disk_type":              "pd-standard",
+			"workercidr":             "10.250.0.0/19",
+			"worker_max_surge":       4,
+			"worker_max_unavailable": 1,
+			"worker_maximum":         6,
+			"worker_minimum":         3,
+			"zones":                  []string{"europe-west3-b"},
+			"gcp_control_plane_zone": "europe-west3-b",
+			"networking_type":        "calico",
+		},
+	}
+
+	result := &types.ClusterInfo{
+		Endpoint: "https://cluster-url.fake",
+		Status: &types.ClusterStatus{
+			Phase: types.Provisioned,
+		},
+	}
+	mockOp.On("Update", mock.Anything, cluster.ClusterInfo, types.Gardener, g.loadConfigurations(cluster, provider)).Return(result, nil).Once()
+
+	cluster, err := g.Update(context.Background(), cluster, provider)
+	require.NoError(t, err, "Update should succeed")
+	require.Equal(t, result, cluster.ClusterInfo, "The cluster info returned from the operator should be in the cluster returned by Update")
+
+	mockOp.On("Update", mock.Anything, cluster.ClusterInfo, types.Gardener, g.loadConfigurations(cluster, provider)).Return(nil, errors.New("Unable to update cluster"))
+
+	_, err = g.Update(context.Background(), cluster, provider)
+	require.Error(t, err, "Update should fail")
+}
+
+func TestHibernation(t *testing.T) {
+	t.Parallel()
+	mockOp := &mocks.Operator{}
+	g := GardenerProvisioner{
+		operator: mockOp,
+	}
+
+	cluster := &types.Cluster{
+		CPU:               1,
+		KubernetesVersion: "1.27",
+		Name:              "hydro-cluster",
+		DiskSizeGB:        30,
+		NodeCount:         2,
+		Location:          "europe-west3",
+		MachineType:       "type1",
+		ClusterInfo:       &types.ClusterInfo{},
+	}
+	provider := &types.Provider{
+		Type:                types.Gardener,
+		ProjectName:         "my-project",
+		CredentialsFilePath: "/path/to/credentials",
+		CustomConfigurations: map[string]interface{}{
+			"target_provider":        "gcp",
+			"target_secret":          "secret-name",
+			"disk_type":              "pd-standard",
+			"workercidr":             "10.250.0.0/19",
+			"worker_max_surge":       4,
+			"worker_max_unavailable": 1,
+			"worker_maximum":         4,
+			"worker_minimum":         2,
+			"zones":                  []string{"europe-west3-b"},
+			"gcp_control_plane_zone": "europe-west3-b",
+			"networking_type":        "calico",
+		},
+	}
+	cfg := g.loadConfigurations(cluster, provider)
+
+	hibernated := &types.ClusterInfo{Status: &types.ClusterStatus{Phase: types.Hibernated}}
+	mockOp.On("Hibernate", mock.Anything, cluster.ClusterInfo, types.Gardener, cfg).Return(hibernated, nil).Once()
+
+	cluster, err := g.Hibernate(context.Background(), cluster, provider)
+	require.NoError(t, err, "Hibernate should succeed")
+	require.Equal(t, types.Hibernated, cluster.ClusterInfo.Status.Phase)
+
+	running := &types.ClusterInfo{Status: &types.ClusterStatus{Phase: types.Provisioned}}
+	mockOp.On("WakeUp", mock.Anything, cluster.ClusterInfo, types.Gardener, cfg).Return(running, nil).Once()
+
+	cluster, err = g.WakeUp(context.Background(), cluster, provider)
+	require.NoError(t, err, "WakeUp should succeed")
+	require.Equal(t, types.Provisioned, cluster.ClusterInfo.Status.Phase)
+
+	mockOp.On("WakeUp", mock.Anything, cluster.ClusterInfo, types.Gardener, cfg).Return(nil, errors.New("Unable to wake up cluster"))
+
+	_, err = g.WakeUp(context.Background(), cluster, provider)
+	require.Error(t, err, "WakeUp should fail")
+}
+
+func TestValidateWorkerPools(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		pools   []types.WorkerPool
+		isValid bool
+	}{
+		{
+			name: "Valid pools",
+			pools: []types.WorkerPool{
+				{Name: "system", Minimum: 1, Maximum: 3, Taints: []types.Taint{{Key: "dedicated", Effect: types.TaintEffectNoSchedule}}},
+				{Name: "batch", Minimum: 0, Maximum: 10},
+			},
+			isValid: true,
+		},
+		{
+			name:  "Invalid name",
+			pools: []types.WorkerPool{{Name: "Batch_Pool", Maximum: 1}},
+		},
+		{
+			name:  "Name too long",
+			pools: []types.WorkerPool{{Name: "this-name-is-too-long", Maximum: 1}},
+		},
+		{
+			name:  "Duplicate name",
+			pools: []types.WorkerPool{{Name: "batch", Maximum: 1}, {Name: "batch", Maximum: 1}},
+		},
+		{
+			name:  "Maximum not set",
+			pools: []types.WorkerPool{{Name: "batch"}},
+		},
+		{
+			name:  "Maximum less than minimum",
+			pools: []types.WorkerPool{{Name: "batch", Minimum: 3, Maximum: 1}},
+		},
+		{
+			name:  "Invalid taint effect",
+			pools: []types.WorkerPool{{Name: "batch", Maximum: 1, Taints: []types.Taint{{Key: "dedicated", Effect: "Never"}}}},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			msg := validateWorkerPools(tc.pools)
+			if tc.isValid {
+				require.Empty(t, msg)
+			} else {
+				require.NotEmpty(t, msg)
+			}
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	t.Parallel()
+	g := GardenerProvisioner{}
+
+	cluster := &types.Cluster{
+		KubernetesVersion: "1.26.8",
+		Name:              "hydro-cluster",
+		DiskSizeGB:        30,
+		NodeCount:         2,
+		Location:          "europe-west3",
+		MachineType:       "n1-standard-4",
+	}
+	provider := &types.Provider{
+		Type:        types.Gardener,
+		ProjectName: "my-project",
+		CustomConfigurations: map[string]interface{}{
+			"target_provider":        "gcp",
+			"target_secret":          "secret-name",
+			"disk_type":              "pd-standard",
+			"workercidr":             "10.250.0.0/19",
+			"worker_max_surge":       4,
+			"worker_max_unavailable": 1,
+			"worker_maximum":         4,
+			"worker_minimum":         2,
+			"zones":                  []string{"europe-west3-b"},
+			"gcp_control_plane_zone": "europe-west3-b",
+			"networking_type":        "calico",
+		},
+	}
+
+	plan, err := g.Plan(cluster, provider)
+	require.NoError(t, err, "Plan should not need the credentials")
+	out, err := plan.YAML()
+	require.NoError(t, err)
+	require.Contains(t, string(out), "kind: Shoot")
+	require.Contains(t, string(out), "namespace: garden-my-project")
+	require.Contains(t, string(out), "kind: InfrastructureConfig", "The provider configuration should be rendered")
+	_, err = plan.JSON()
+	require.NoError(t, err)
+
+	cluster.NodeCount = 0
+	_, err = g.Plan(cluster, provider)
+	require.Error(t, err, "Plan should validate the cluster")
+}
+
+func TestImportManifest(t *testing.T) {
+	t.Parallel()
+	g := GardenerProvisioner{}
+
+	minimum, maximum, surge, unavailable := 1, 3, 2, 0
+	providers := map[string]*types.GardenerConfig{
+		"gcp": {TargetProvider: types.GCP, GCP: &types.GardenerGCPConfig{ControlPlaneZone: "europe-west3-b", WorkerCIDR: "10.250.0.0/19"}},
+		"aws": {TargetProvider: types.AWS, AWS: &types.GardenerAWSConfig{VnetCIDR: "10.250.0.0/16"}},
+		"azure": {TargetProvider: types.Azure, MachineImageName: "gardenlinux", MachineImageVersion: "934.8.0",
+			Azure: &types.GardenerAzureConfig{VnetCIDR: "10.250.0.0/16", WorkerCIDR: "10.250.0.0/19", ServiceEndpoints: []string{"Microsoft.Storage"}}},
+	}
+	for name, cfg := range providers {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cfg.TargetSecret = "secret-name"
+			cfg.DiskType = "standard"
+			cfg.NetworkingType = "calico"
+			cfg.Zones = []string{"zone-a"}
+			cfg.WorkerMinimum, cfg.WorkerMaximum = &minimum, &maximum
+			cfg.WorkerMaxSurge, cfg.WorkerMaxUnavailable = &surge, &unavailable
+			cfg.HibernationStart = "00 20 * * 1,2,3,4,5"
+			cfg.OIDCClientID = "hydro"
+			cfg.OIDCIssuerURL = "https://issuer.example.com"
+
+			cluster := &types.Cluster{
+				KubernetesVersion: "1.26.8",
+				Name:              "hydro-cluster",
+				DiskSizeGB:        50,
+				NodeCount:         1,
+				Location:          "europe-west3",
+				MachineType:       "n1-standard-4",
+			}
+			provider := &types.Provider{Type: types.Gardener, ProjectName: "my-project", Gardener: cfg}
+
+			plan, err := g.Plan(cluster, provider)
+			require.NoError(t, err)
+			manifest, err := plan.YAML()
+			require.NoError(t, err)
+
+			imported, importedProvider, err := g.ImportManifest(manifest, &types.Provider{CredentialsFilePath: "/path/to/credentials"})
+			require.NoError(t, err)
+			require.Equal(t, "my-project", importedProvider.ProjectName)
+			require.Equal(t, "/path/to/credentials", importedProvider.CredentialsFilePath)
+			require.Empty(t, imported.WorkerPools, "A single default worker should not be imported as a pool")
+			require.NoError(t, g.validate(imported, importedProvider), "The imported cluster should be valid")
+
+			replanned, err := g.Plan(imported, importedProvider)
+			require.NoError(t, err)
+			require.Equal(t, plan.Manifest, replanned.Manifest, "The imported cluster should render the same shoot")
+		})
+	}
+
+	_, _, err := g.ImportManifest([]byte("kind: Seed\nmetadata:\n  name: hydro"), nil)
+	require.EqualError(t, err, "the manifest describes a Seed, not a Shoot")
+}
@@ -1,14 +1,15 @@
 package gcp
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 
 	"github.com/pkg/errors"
 
 	"github.com/kyma-project/hydroform/provision/internal/errs"
-	"github.com/kyma-project/hydroform/provision/internal/operator"
-	"github.com/kyma-project/hydroform/provision/internal/operator/native"
+	"github.com/kyma-project/hydroform/provision/operator"
+	"github.com/kyma-project/hydroform/provision/provider"
 	"github.com/kyma-project/hydroform/provision/types"
 )
 
@@ -18,6 +19,12 @@ type GcpProvisioner struct {
 	provisionOperator operator.Operator
 }
 
+func init() {
+	provider.Register(types.GCP, func(operatorType operator.Type, ops ...types.Option) provider.Provisioner {
+		return New(operatorType, ops...)
+	})
+}
+
 // New creates a new instance of GcpProvisioner.
 func New(operatorType operator.Type, ops ...types.Option) *GcpProvisioner {
 	// parse config
@@ -26,13 +33,7 @@ func New(operatorType operator.Type, ops ...types.Option) *GcpProvisioner {
 		o(os)
 	}
 
-	var op operator.Operator
-	switch operatorType {
-	case operator.NativeOperator:
-		op = native.New(os)
-	default:
-		op = &operator.Unknown{}
-	}
+	op := operator.New(operatorType, os)
 
 	return &GcpProvisioner{
 		provisionOperator: op,
@@ -40,14 +41,14 @@ func New(operatorType operator.Type, ops ...types.Option) *GcpProvisioner {
 }
 
 // Provision requests provisioning of a new Kubernetes cluster on GCP with the given configurations.
-func (g *GcpProvisioner) Provision(cluster *types.Cluster, provider *types.Provider) (*types.Cluster, error) {
+func (g *GcpProvisioner) Provision(ctx context.Context, cluster *types.Cluster, provider *types.Provider) (*types.Cluster, error) {
 	if err := g.validateInputs(cluster, provider); err != nil {
 		return cluster, err
 	}
 
 	config := g.loadConfigurations(cluster, provider)
 
-	clusterInfo, err := g.provisionOperator.Create(provider.Type, config)
+	clusterInfo, err := g.provisionOperator.Create(ctx, provider.Type, config)
 	if err != nil {
 		return cluster, errors.Wrap(err, "unable to provision gcp cluster")
 	}
@@ -57,30 +58,47 @@ func (g *GcpProvisioner) Provision(cluster *types.Cluster, provider *types.Provi
 }
 
 // Status returns the ClusterStatus for the requested cluster.
-func (g *GcpProvisioner) Status(cluster *types.Cluster, p *types.Provider) (*types.ClusterStatus, error) {
+func (g *GcpProvisioner) Status(ctx context.Context, cluster *types.Cluster, p *types.Provider) (*types.ClusterStatus, error) {
 	if err := g.validateInputs(cluster, p); err != nil {
 		return nil, err
 	}
 
 	cfg := g.loadConfigurations(cluster, p)
 
-	return g.provisionOperator.Status(cluster.ClusterInfo, p.Type, cfg)
+	return g.provisionOperator.Status(ctx, cluster.ClusterInfo, p.Type, cfg)
 }
 
 // Credentials returns the Kubeconfig file as a byte array for the requested cluster.
-func (g *GcpProvisioner) Credentials(cluster *types.Cluster, p *types.Provider) ([]byte, error) {
+func (g *GcpProvisioner) Credentials(ctx context.Context, cluster *types.Cluster, p *types.Provider) ([]byte, error) {
 	return nil, errors.New("Not supported")
 }
 
+// Update requests changing an existing cluster on GCP to match the given configurations.
+func (g *GcpProvisioner) Update(ctx context.Context, cluster *types.Cluster, p *types.Provider) (*types.Cluster, error) {
+	if err := g.validateInputs(cluster, p); err != nil {
+		return cluster, err
+	}
+
+	config := g.loadConfigurations(cluster, p)
+
+	clusterInfo, err := g.provisionOperator.Update(ctx, cluster.ClusterInfo, p.Type, config)
+	if err != nil {
+		return cluster, errors.Wrap(err, "unable to update gcp cluster")
+	}
+
+	cluster.ClusterInfo = clusterInfo
+	return cluster, nil
+}
+
 // Deprovision requests deprovisioning of an existing cluster on GCP with the given configurations.
-func (g *GcpProvisioner) Deprovision(cluster *types.Cluster, p *types.Provider) error {
+func (g *GcpProvisioner) Deprovision(ctx context.Context, cluster *types.Cluster, p *types.Provider) error {
 	if err := g.validateInputs(cluster, p); err != nil {
 		return err
 	}
 
 	config := g.loadConfigurations(cluster, p)
 
-	err := g.provisionOperator.Delete(cluster.ClusterInfo, p.Type, config)
+	err := g.provisionOperator.Delete(ctx, cluster.ClusterInfo, p.Type, config)
 	if err != nil {
 		return errors.Wrap(err, "unable to deprovision gcp cluster")
 	}
@@ -119,6 +137,12 @@ func (g *GcpProvisioner) validateInputs(cluster *types.Cluster, provider *types.
 		errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.ProjectName")
 	}
 
+	if cfg, err := provider.Config(); err != nil {
+		errMessage += errs.Config(err)
+	} else {
+		errMessage += errs.Config(cfg.Validate())
+	}
+
 	if errMessage != "" {
 		return errors.New("input validation failed with the following information: " + errMessage)
 	}
@@ -136,7 +160,13 @@ func (g *GcpProvisioner) loadConfigurations(cluster *types.Cluster, provider *ty
 	config["location"] = cluster.Location
 	config["project"] = provider.ProjectName
 	config["credentials_file_path"] = provider.CredentialsFilePath
-	for k, v := range provider.CustomConfigurations {
+
+	custom, err := provider.Configurations()
+	if err != nil {
+		// invalid values are reported by validateInputs
+		custom = provider.CustomConfigurations
+	}
+	for k, v := range custom {
 		config[k] = v
 	}
 	return config
@@ -1,6 +1,7 @@
 package gcp
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/kyma-project/hydroform/provision/types"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -39,6 +41,10 @@ func TestValidateInputs(t *testing.T) {
 
 	require.NoError(t, g.validateInputs(cluster, provider), "Validation should pass")
 
+	provider.GCP = &types.GCPConfig{NodePoolName: "Invalid_Pool"}
+	require.ErrorContains(t, g.validateInputs(cluster, provider), "Provider.CustomConfigurations['node_pool_name']", "Validation should fail when the node pool name is invalid")
+	provider.GCP = nil
+
 	cluster.NodeCount = -5
 	require.Error(t, g.validateInputs(cluster, provider), "Validation should fail when number of nodes is < 1")
 	cluster.NodeCount = 2
@@ -131,6 +137,11 @@ func TestLoadConfigurations(t *testing.T) {
 	for k, v := range provider.CustomConfigurations {
 		require.Equal(t, v, config[k], fmt.Sprintf("Custom config %s is incorrect", k))
 	}
+
+	provider.CustomConfigurations["node_pool_name"] = "legacy"
+	provider.GCP = &types.GCPConfig{NodePoolName: "system"}
+	config = g.loadConfigurations(cluster, provider)
+	require.Equal(t, "system", config["node_pool_name"], "The typed configuration should take precedence")
 }
 
 func TestProvision(t *testing.T) {
@@ -168,18 +179,18 @@ func TestProvision(t *testing.T) {
 			Phase: types.Provisioned,
 		},
 	}
-	mockOp.On("Create", types.GCP, g.loadConfigurations(cluster, provider)).Return(result, nil)
+	mockOp.On("Create", mock.Anything, types.GCP, g.loadConfigurations(cluster, provider)).Return(result, nil)
 
-	cluster, err := g.Provision(cluster, provider)
+	cluster, err := g.Provision(context.Background(), cluster, provider)
 	require.NoError(t, err, "Provision should succeed")
 	require.Equal(t, result, cluster.ClusterInfo, "The cluster info returned from the operator should be in the cluster returned by Provision")
 
 	badCluster := &types.Cluster{
 		CPU: 1,
 	}
-	mockOp.On("Create", types.GCP, g.loadConfigurations(badCluster, provider)).Return(badCluster, errors.New("Unable to provision cluster"))
+	mockOp.On("Create", mock.Anything, types.GCP, g.loadConfigurations(badCluster, provider)).Return(badCluster, errors.New("Unable to provision cluster"))
 
-	_, err = g.Provision(badCluster, provider)
+	_, err = g.Provision(context.Background(), badCluster, provider)
 	require.Error(t, err, "Provision should fail")
 }
 
@@ -213,14 +224,14 @@ func TestDeprovision(t *testing.T) {
 		},
 	}
 
-	mockOp.On("Delete", cluster.ClusterInfo, types.GCP, g.loadConfigurations(cluster, provider)).Return(nil)
+	mockOp.On("Delete", mock.Anything, cluster.ClusterInfo, types.GCP, g.loadConfigurations(cluster, provider)).Return(nil)
 
-	err := g.Deprovision(cluster, provider)
+	err := g.Deprovision(context.Background(), cluster, provider)
 	require.NoError(t, err, "Deprovision should succeed")
 
 	provider.CredentialsFilePath = "/wrong/credentials"
-	mockOp.On("Delete", cluster.ClusterInfo, types.GCP, g.loadConfigurations(cluster, provider)).Return(errors.New("Unable to deprovision cluster"))
+	mockOp.On("Delete", mock.Anything, cluster.ClusterInfo, types.GCP, g.loadConfigurations(cluster, provider)).Return(errors.New("Unable to deprovision cluster"))
 
-	err = g.Deprovision(cluster, provider)
+	err = g.Deprovision(context.Background(), cluster, provider)
 	require.Error(t, err, "Deprovision should fail")
 }
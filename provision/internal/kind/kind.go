@@ -1,12 +1,13 @@
 package kind
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 
 	"github.com/kyma-project/hydroform/provision/internal/errs"
-	"github.com/kyma-project/hydroform/provision/internal/operator"
-	"github.com/kyma-project/hydroform/provision/internal/operator/native"
+	"github.com/kyma-project/hydroform/provision/operator"
+	"github.com/kyma-project/hydroform/provision/provider"
 	"github.com/kyma-project/hydroform/provision/types"
 
 	"github.com/pkg/errors"
@@ -18,6 +19,12 @@ type KindProvisioner struct {
 	provisionOperator operator.Operator
 }
 
+func init() {
+	provider.Register(types.Kind, func(operatorType operator.Type, ops ...types.Option) provider.Provisioner {
+		return New(operatorType, ops...)
+	})
+}
+
 // New creates a new instance of KindProvisioner.
 func New(operatorType operator.Type, ops ...types.Option) *KindProvisioner {
 	// parse config
@@ -26,13 +33,7 @@ func New(operatorType operator.Type, ops ...types.Option) *KindProvisioner {
 		o(os)
 	}
 
-	var op operator.Operator
-	switch operatorType {
-	case operator.NativeOperator:
-		op = native.New(os)
-	default:
-		op = &operator.Unknown{}
-	}
+	op := operator.New(operatorType, os)
 
 	return &KindProvisioner{
 		provisionOperator: op,
@@ -40,14 +41,14 @@ func New(operatorType operator.Type, ops ...types.Option) *KindProvisioner {
 }
 
 // Provision requests provisioning of a new Kubernetes cluster on Kind with the given configurations.
-func (k *KindProvisioner) Provision(cluster *types.Cluster, p *types.Provider) (*types.Cluster, error) {
+func (k *KindProvisioner) Provision(ctx context.Context, cluster *types.Cluster, p *types.Provider) (*types.Cluster, error) {
 	if err := k.validateInputs(cluster, p); err != nil {
 		return nil, err
 	}
 
 	config := k.loadConfigurations(cluster, p)
 
-	clusterInfo, err := k.provisionOperator.Create(p.Type, config)
+	clusterInfo, err := k.provisionOperator.Create(ctx, p.Type, config)
 	if err != nil {
 		return cluster, errors.Wrap(err, "unable to provision kind cluster")
 	}
@@ -57,30 +58,58 @@ func (k *KindProvisioner) Provision(cluster *types.Cluster, p *types.Provider) (
 }
 
 // Status returns the ClusterStatus for the requested cluster.
-func (k *KindProvisioner) Status(cluster *types.Cluster, p *types.Provider) (*types.ClusterStatus, error) {
+func (k *KindProvisioner) Status(ctx context.Context, cluster *types.Cluster, p *types.Provider) (*types.ClusterStatus, error) {
 	if err := k.validateInputs(cluster, p); err != nil {
 		return nil, err
 	}
 
 	cfg := k.loadConfigurations(cluster, p)
 
-	return k.provisionOperator.Status(cluster.ClusterInfo, p.Type, cfg)
+	return k.provisionOperator.Status(ctx, cluster.ClusterInfo, p.Type, cfg)
 }
 
 // Credentials returns the Kubeconfig file as a byte array for the requested cluster.
-func (k *KindProvisioner) Credentials(cluster *types.Cluster, p *types.Provider) ([]byte, error) {
-	return nil, errors.New("Not supported")
+func (k *KindProvisioner) Credentials(ctx context.Context, cluster *types.Cluster, p *types.Provider) ([]byte, error) {
+	if err := k.validateInputs(cluster, p); err != nil {
+		return nil, err
+	}
+
+	config := k.loadConfigurations(cluster, p)
+
+	kubeconfig, err := k.provisionOperator.Credentials(ctx, cluster.ClusterInfo, p.Type, config)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to fetch kind cluster credentials")
+	}
+
+	return kubeconfig, nil
+}
+
+// Update requests changing an existing cluster on Kind to match the given configurations.
+func (k *KindProvisioner) Update(ctx context.Context, cluster *types.Cluster, p *types.Provider) (*types.Cluster, error) {
+	if err := k.validateInputs(cluster, p); err != nil {
+		return cluster, err
+	}
+
+	config := k.loadConfigurations(cluster, p)
+
+	clusterInfo, err := k.provisionOperator.Update(ctx, cluster.ClusterInfo, p.Type, config)
+	if err != nil {
+		return cluster, errors.Wrap(err, "unable to update kind cluster")
+	}
+
+	cluster.ClusterInfo = clusterInfo
+	return cluster, nil
 }
 
 // Deprovision requests deprovisioning of an existing cluster on Kind with the given configurations.
-func (k *KindProvisioner) Deprovision(cluster *types.Cluster, p *types.Provider) error {
+func (k *KindProvisioner) Deprovision(ctx context.Context, cluster *types.Cluster, p *types.Provider) error {
 	if err := k.validateInputs(cluster, p); err != nil {
 		return err
 	}
 
 	config := k.loadConfigurations(cluster, p)
 
-	err := k.provisionOperator.Delete(cluster.ClusterInfo, p.Type, config)
+	err := k.provisionOperator.Delete(ctx, cluster.ClusterInfo, p.Type, config)
 	if err != nil {
 		return errors.Wrap(err, "unable to deprovision kind cluster")
 	}
@@ -106,6 +135,11 @@ func (k *KindProvisioner) validateInputs(cluster *types.Cluster, provider *types
 			errMessage += fmt.Sprintf(errs.CannotBeEmpty, "Provider.CustomConfiguration.node_image")
 		}
 	}
+	if cfg, err := provider.Config(); err != nil {
+		errMessage += errs.Config(err)
+	} else {
+		errMessage += errs.Config(cfg.Validate())
+	}
 
 	if errMessage != "" {
 		return errors.New("input validation failed with the following information: " + errMessage)
@@ -117,8 +151,15 @@ func (k *KindProvisioner) validateInputs(cluster *types.Cluster, provider *types
 func (k *KindProvisioner) loadConfigurations(cluster *types.Cluster, p *types.Provider) map[string]interface{} {
 	config := map[string]interface{}{}
 	config["cluster_name"] = cluster.Name
+	config["node_count"] = cluster.NodeCount
 	config["project"] = p.ProjectName
-	for k, v := range p.CustomConfigurations {
+
+	custom, err := p.Configurations()
+	if err != nil {
+		// invalid values are reported by validateInputs
+		custom = p.CustomConfigurations
+	}
+	for k, v := range custom {
 		config[k] = v
 	}
 	return config
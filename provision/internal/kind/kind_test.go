@@ -1,12 +1,14 @@
 package kind
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/kyma-project/hydroform/provision/internal/operator/mocks"
 	"github.com/kyma-project/hydroform/provision/types"
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -96,18 +98,18 @@ func TestProvision(t *testing.T) {
 			Phase: types.Provisioned,
 		},
 	}
-	mockOp.On("Create", types.Kind, k.loadConfigurations(cluster, provider)).Return(result, nil)
+	mockOp.On("Create", mock.Anything, types.Kind, k.loadConfigurations(cluster, provider)).Return(result, nil)
 
-	cluster, err := k.Provision(cluster, provider)
+	cluster, err := k.Provision(context.Background(), cluster, provider)
 	require.NoError(t, err, "Provision should succeed")
 	require.Equal(t, result, cluster.ClusterInfo, "The cluster info returned from the operator should be in the cluster returned by Provision")
 
 	badCluster := &types.Cluster{
 		Name: "",
 	}
-	mockOp.On("Create", types.Kind, k.loadConfigurations(badCluster, provider)).Return(badCluster, errors.New("Unable to provision cluster"))
+	mockOp.On("Create", mock.Anything, types.Kind, k.loadConfigurations(badCluster, provider)).Return(badCluster, errors.New("Unable to provision cluster"))
 
-	_, err = k.Provision(badCluster, provider)
+	_, err = k.Provision(context.Background(), badCluster, provider)
 	require.Error(t, err, "Provision should fail")
 }
 
@@ -129,14 +131,39 @@ func TestDeprovision(t *testing.T) {
 		},
 	}
 
-	mockOp.On("Delete", cluster.ClusterInfo, types.Kind, k.loadConfigurations(cluster, provider)).Return(nil)
+	mockOp.On("Delete", mock.Anything, cluster.ClusterInfo, types.Kind, k.loadConfigurations(cluster, provider)).Return(nil)
 
-	err := k.Deprovision(cluster, provider)
+	err := k.Deprovision(context.Background(), cluster, provider)
 	require.NoError(t, err, "Deprovision should succeed")
 
 	provider.ProjectName = ""
-	mockOp.On("Delete", cluster.ClusterInfo, types.Kind, k.loadConfigurations(cluster, provider)).Return(errors.New("Unable to deprovision cluster"))
+	mockOp.On("Delete", mock.Anything, cluster.ClusterInfo, types.Kind, k.loadConfigurations(cluster, provider)).Return(errors.New("Unable to deprovision cluster"))
 
-	err = k.Deprovision(cluster, provider)
+	err = k.Deprovision(context.Background(), cluster, provider)
 	require.Error(t, err, "Deprovision should fail")
 }
+
+func TestCredentials(t *testing.T) {
+	t.Parallel()
+	mockOp := &mocks.Operator{}
+	k := KindProvisioner{
+		provisionOperator: mockOp,
+	}
+
+	cluster := &types.Cluster{
+		Name: "test-cluster",
+	}
+	provider := &types.Provider{
+		Type:        types.Kind,
+		ProjectName: "my-project",
+		CustomConfigurations: map[string]interface{}{
+			"node_image": "somerepo/image:v0.0.0",
+		},
+	}
+
+	mockOp.On("Credentials", mock.Anything, cluster.ClusterInfo, types.Kind, k.loadConfigurations(cluster, provider)).Return([]byte("kubeconfig"), nil)
+
+	kubeconfig, err := k.Credentials(context.Background(), cluster, provider)
+	require.NoError(t, err, "Credentials should succeed")
+	require.Equal(t, []byte("kubeconfig"), kubeconfig)
+}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
 
 	types "github.com/kyma-project/hydroform/provision/types"
@@ -13,13 +15,13 @@ type Operator struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: p, cfg
-func (_m *Operator) Create(p types.ProviderType, cfg map[string]interface{}) (*types.ClusterInfo, error) {
-	ret := _m.Called(p, cfg)
+// Create provides a mock function with given fields: ctx, p, cfg
+func (_m *Operator) Create(ctx context.Context, p types.ProviderType, cfg map[string]interface{}) (*types.ClusterInfo, error) {
+	ret := _m.Called(ctx, p, cfg)
 
 	var r0 *types.ClusterInfo
-	if rf, ok := ret.Get(0).(func(types.ProviderType, map[string]interface{}) *types.ClusterInfo); ok {
-		r0 = rf(p, cfg)
+	if rf, ok := ret.Get(0).(func(context.Context, types.ProviderType, map[string]interface{}) *types.ClusterInfo); ok {
+		r0 = rf(ctx, p, cfg)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*types.ClusterInfo)
@@ -27,8 +29,31 @@ func (_m *Operator) Create(p types.ProviderType, cfg map[string]interface{}) (*t
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(types.ProviderType, map[string]interface{}) error); ok {
-		r1 = rf(p, cfg)
+	if rf, ok := ret.Get(1).(func(context.Context, types.ProviderType, map[string]interface{}) error); ok {
+		r1 = rf(ctx, p, cfg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Credentials provides a mock function with given fields: ctx, info, p, cfg
+func (_m *Operator) Credentials(ctx context.Context, info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) ([]byte, error) {
+	ret := _m.Called(ctx, info, p, cfg)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(context.Context, *types.ClusterInfo, types.ProviderType, map[string]interface{}) []byte); ok {
+		r0 = rf(ctx, info, p, cfg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *types.ClusterInfo, types.ProviderType, map[string]interface{}) error); ok {
+		r1 = rf(ctx, info, p, cfg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -36,13 +61,13 @@ func (_m *Operator) Create(p types.ProviderType, cfg map[string]interface{}) (*t
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: info, p, cfg
-func (_m *Operator) Delete(info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) error {
-	ret := _m.Called(info, p, cfg)
+// Delete provides a mock function with given fields: ctx, info, p, cfg
+func (_m *Operator) Delete(ctx context.Context, info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) error {
+	ret := _m.Called(ctx, info, p, cfg)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*types.ClusterInfo, types.ProviderType, map[string]interface{}) error); ok {
-		r0 = rf(info, p, cfg)
+	if rf, ok := ret.Get(0).(func(context.Context, *types.ClusterInfo, types.ProviderType, map[string]interface{}) error); ok {
+		r0 = rf(ctx, info, p, cfg)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -50,13 +75,36 @@ func (_m *Operator) Delete(info *types.ClusterInfo, p types.ProviderType, cfg ma
 	return r0
 }
 
-// Status provides a mock function with given fields: info, p, cfg
-func (_m *Operator) Status(info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) (*types.ClusterStatus, error) {
-	ret := _m.Called(info, p, cfg)
+// Hibernate provides a mock function with given fields: ctx, info, p, cfg
+func (_m *Operator) Hibernate(ctx context.Context, info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) (*types.ClusterInfo, error) {
+	ret := _m.Called(ctx, info, p, cfg)
+
+	var r0 *types.ClusterInfo
+	if rf, ok := ret.Get(0).(func(context.Context, *types.ClusterInfo, types.ProviderType, map[string]interface{}) *types.ClusterInfo); ok {
+		r0 = rf(ctx, info, p, cfg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ClusterInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *types.ClusterInfo, types.ProviderType, map[string]interface{}) error); ok {
+		r1 = rf(ctx, info, p, cfg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Status provides a mock function with given fields: ctx, info, p, cfg
+func (_m *Operator) Status(ctx context.Context, info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) (*types.ClusterStatus, error) {
+	ret := _m.Called(ctx, info, p, cfg)
 
 	var r0 *types.ClusterStatus
-	if rf, ok := ret.Get(0).(func(*types.ClusterInfo, types.ProviderType, map[string]interface{}) *types.ClusterStatus); ok {
-		r0 = rf(info, p, cfg)
+	if rf, ok := ret.Get(0).(func(context.Context, *types.ClusterInfo, types.ProviderType, map[string]interface{}) *types.ClusterStatus); ok {
+		r0 = rf(ctx, info, p, cfg)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*types.ClusterStatus)
@@ -64,8 +112,54 @@ func (_m *Operator) Status(info *types.ClusterInfo, p types.ProviderType, cfg ma
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*types.ClusterInfo, types.ProviderType, map[string]interface{}) error); ok {
-		r1 = rf(info, p, cfg)
+	if rf, ok := ret.Get(1).(func(context.Context, *types.ClusterInfo, types.ProviderType, map[string]interface{}) error); ok {
+		r1 = rf(ctx, info, p, cfg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, info, p, cfg
+func (_m *Operator) Update(ctx context.Context, info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) (*types.ClusterInfo, error) {
+	ret := _m.Called(ctx, info, p, cfg)
+
+	var r0 *types.ClusterInfo
+	if rf, ok := ret.Get(0).(func(context.Context, *types.ClusterInfo, types.ProviderType, map[string]interface{}) *types.ClusterInfo); ok {
+		r0 = rf(ctx, info, p, cfg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ClusterInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *types.ClusterInfo, types.ProviderType, map[string]interface{}) error); ok {
+		r1 = rf(ctx, info, p, cfg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WakeUp provides a mock function with given fields: ctx, info, p, cfg
+func (_m *Operator) WakeUp(ctx context.Context, info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) (*types.ClusterInfo, error) {
+	ret := _m.Called(ctx, info, p, cfg)
+
+	var r0 *types.ClusterInfo
+	if rf, ok := ret.Get(0).(func(context.Context, *types.ClusterInfo, types.ProviderType, map[string]interface{}) *types.ClusterInfo); ok {
+		r0 = rf(ctx, info, p, cfg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ClusterInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *types.ClusterInfo, types.ProviderType, map[string]interface{}) error); ok {
+		r1 = rf(ctx, info, p, cfg)
 	} else {
 		r1 = ret.Error(1)
 	}
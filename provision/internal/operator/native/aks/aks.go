@@ -0,0 +1,287 @@
+package aks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/kyma-project/hydroform/provision/internal/operator/native/kubeconfig"
+	"github.com/kyma-project/hydroform/provision/internal/operator/native/poll"
+	"github.com/kyma-project/hydroform/provision/internal/operator/native/rest"
+	"github.com/kyma-project/hydroform/provision/types"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	// DefaultEndpoint is the base URL of the Azure Resource Manager API.
+	DefaultEndpoint   = "https://management.azure.com"
+	apiVersion        = "2023-08-01"
+	managementScope   = "https://management.azure.com/.default"
+	tokenURLTemplate  = "https://login.microsoftonline.com/%s/oauth2/v2.0/token"
+	defaultPoolName   = "agentpool"
+	systemPoolMode    = "System"
+	adminKubeconfigID = "clusterAdmin"
+)
+
+/*-- AKS native operator --*/
+
+func Create(ctx context.Context, ops *types.Options, cfg map[string]interface{}) (*types.ClusterInfo, error) {
+	client, err := clientFromConfig(ctx, ops, cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the AKS client from credentials")
+	}
+	return client.Create(ctx, ops, cfg)
+}
+
+func Status(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) (*types.ClusterStatus, error) {
+	client, err := clientFromConfig(ctx, ops, cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the AKS client from credentials")
+	}
+	return client.Status(ctx, ops, info, cfg)
+}
+
+func Credentials(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) ([]byte, error) {
+	client, err := clientFromConfig(ctx, ops, cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the AKS client from credentials")
+	}
+	return client.Credentials(ctx, ops, info, cfg)
+}
+
+func Delete(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) error {
+	client, err := clientFromConfig(ctx, ops, cfg)
+	if err != nil {
+		return errors.Wrap(err, "error creating the AKS client from credentials")
+	}
+	return client.Delete(ctx, ops, info, cfg)
+}
+
+/*-- AKS client --*/
+
+// Client performs managed cluster operations against the Azure Resource Manager API.
+type Client struct {
+	httpClient     *http.Client
+	endpoint       string
+	subscriptionID string
+}
+
+// NewClient creates an AKS client that sends its requests with the given HTTP client to the given API endpoint.
+// The HTTP client is responsible for authenticating the requests.
+func NewClient(httpClient *http.Client, endpoint, subscriptionID string) *Client {
+	return &Client{
+		httpClient:     httpClient,
+		endpoint:       endpoint,
+		subscriptionID: subscriptionID,
+	}
+}
+
+// clientFromConfig creates an AKS client authenticated with the service principal in the configuration.
+// It fails if any of the credentials is missing or is not a string.
+func clientFromConfig(ctx context.Context, ops *types.Options, cfg map[string]interface{}) (*Client, error) {
+	azureCfg, err := (&types.Provider{Type: types.Azure, CustomConfigurations: cfg}).Config()
+	if err != nil {
+		return nil, err
+	}
+	if err := azureCfg.Validate(); err != nil {
+		return nil, err
+	}
+	creds := azureCfg.(*types.AzureConfig)
+
+	conf := &clientcredentials.Config{
+		ClientID:     creds.ClientID,
+		ClientSecret: creds.ClientSecret,
+		TokenURL:     fmt.Sprintf(tokenURLTemplate, creds.TenantID),
+		Scopes:       []string{managementScope},
+	}
+	return NewClient(rest.WithLogging(conf.Client(ctx), ops.Log()), DefaultEndpoint, creds.SubscriptionID), nil
+}
+
+// Create creates a new AKS cluster and waits until its provisioning succeeded.
+func (c *Client) Create(ctx context.Context, ops *types.Options, cfg map[string]interface{}) (*types.ClusterInfo, error) {
+	resourceGroup, name := clusterPath(cfg)
+
+	if err := rest.Do(ctx, c.httpClient, http.MethodPut, c.clusterURL(resourceGroup, name, ""), toManagedCluster(cfg), nil); err != nil {
+		return &types.ClusterInfo{
+			Status: &types.ClusterStatus{
+				Phase: types.Errored,
+			},
+		}, err
+	}
+
+	var cluster *ManagedCluster
+	err := poll.Until(ctx, ops.Log(), types.CreateOperation, ops.PollingInterval(types.CreateOperation), ops.Timeout(types.CreateOperation),
+		func(ctx context.Context) (bool, error) {
+			var err error
+			if cluster, err = c.get(ctx, resourceGroup, name); err != nil {
+				return false, err
+			}
+			switch provisioningState(cluster) {
+			case StateFailed, StateCanceled:
+				return false, errors.Errorf("AKS cluster %s provisioning ended in state %s", name, provisioningState(cluster))
+			case StateSucceeded:
+				return true, nil
+			}
+			return false, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	info := &types.ClusterInfo{
+		Endpoint: fmt.Sprintf("https://%s", cluster.Properties.Fqdn),
+		Status:   toClusterStatus(cluster),
+	}
+
+	kc, err := c.adminKubeconfig(ctx, resourceGroup, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not fetch the admin kubeconfig")
+	}
+	if _, info.CertificateAuthorityData, err = kubeconfig.ClusterAccess(kc); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// Status returns the status of an existing AKS cluster.
+func (c *Client) Status(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) (*types.ClusterStatus, error) {
+	resourceGroup, name := clusterPath(cfg)
+
+	cluster, err := c.get(ctx, resourceGroup, name)
+	if err != nil {
+		return &types.ClusterStatus{
+			Phase: types.Errored,
+		}, err
+	}
+	return toClusterStatus(cluster), nil
+}
+
+// Credentials returns the admin kubeconfig of an existing AKS cluster.
+func (c *Client) Credentials(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) ([]byte, error) {
+	resourceGroup, name := clusterPath(cfg)
+	return c.adminKubeconfig(ctx, resourceGroup, name)
+}
+
+// Delete deletes an AKS cluster and waits until it is gone.
+func (c *Client) Delete(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) error {
+	resourceGroup, name := clusterPath(cfg)
+
+	if err := rest.Do(ctx, c.httpClient, http.MethodDelete, c.clusterURL(resourceGroup, name, ""), nil, nil); err != nil {
+		if rest.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	return poll.Until(ctx, ops.Log(), types.DeleteOperation, ops.PollingInterval(types.DeleteOperation), ops.Timeout(types.DeleteOperation),
+		func(ctx context.Context) (bool, error) {
+			_, err := c.get(ctx, resourceGroup, name)
+			if rest.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		})
+}
+
+func (c *Client) get(ctx context.Context, resourceGroup, name string) (*ManagedCluster, error) {
+	cluster := &ManagedCluster{}
+	if err := rest.Do(ctx, c.httpClient, http.MethodGet, c.clusterURL(resourceGroup, name, ""), nil, cluster); err != nil {
+		return nil, err
+	}
+	return cluster, nil
+}
+
+func (c *Client) adminKubeconfig(ctx context.Context, resourceGroup, name string) ([]byte, error) {
+	creds := &CredentialResults{}
+	if err := rest.Do(ctx, c.httpClient, http.MethodPost, c.clusterURL(resourceGroup, name, "listClusterAdminCredential"), nil, creds); err != nil {
+		return nil, err
+	}
+
+	for _, kc := range creds.Kubeconfigs {
+		if kc.Name == adminKubeconfigID {
+			return kc.Value, nil
+		}
+	}
+	if len(creds.Kubeconfigs) > 0 {
+		return creds.Kubeconfigs[0].Value, nil
+	}
+	return nil, errors.Errorf("no admin kubeconfig available for AKS cluster %s", name)
+}
+
+// clusterURL builds the URL of a managed cluster or of one of its actions if action is not empty.
+func (c *Client) clusterURL(resourceGroup, name, action string) string {
+	url := fmt.Sprintf("%s/subscriptions/%s/resourceGroups/%s/providers/Microsoft.ContainerService/managedClusters/%s",
+		c.endpoint, c.subscriptionID, resourceGroup, name)
+	if action != "" {
+		url = fmt.Sprintf("%s/%s", url, action)
+	}
+	return fmt.Sprintf("%s?api-version=%s", url, apiVersion)
+}
+
+/*-- Cluster building functions --*/
+
+func clusterPath(cfg map[string]interface{}) (resourceGroup, name string) {
+	resourceGroup, _ = cfg["resource_group"].(string)
+	name, _ = cfg["cluster_name"].(string)
+	return
+}
+
+func toManagedCluster(cfg map[string]interface{}) *ManagedCluster {
+	props := &ManagedClusterProperties{}
+	pool := AgentPoolProfile{
+		Name: defaultPoolName,
+		Mode: systemPoolMode,
+	}
+
+	if v, ok := cfg["kubernetes_version"].(string); ok && len(v) > 0 {
+		props.KubernetesVersion = v
+	}
+	if v, ok := cfg["cluster_name"].(string); ok && len(v) > 0 {
+		props.DNSPrefix = v
+	}
+	if v, ok := cfg["agent_count"].(int); ok && v > 0 {
+		pool.Count = v
+	}
+	if v, ok := cfg["agent_vm_size"].(string); ok && len(v) > 0 {
+		pool.VMSize = v
+	}
+	if v, ok := cfg["agent_disk_size"].(int); ok && v > 0 {
+		pool.OSDiskSizeGB = v
+	}
+	if v, ok := cfg["client_id"].(string); ok && len(v) > 0 {
+		props.ServicePrincipalProfile = &ServicePrincipalProfile{ClientID: v}
+		props.ServicePrincipalProfile.Secret, _ = cfg["client_secret"].(string)
+	}
+	props.AgentPoolProfiles = append(props.AgentPoolProfiles, pool)
+
+	location, _ := cfg["location"].(string)
+	return &ManagedCluster{
+		Location:   location,
+		Properties: props,
+	}
+}
+
+func provisioningState(cluster *ManagedCluster) string {
+	if cluster.Properties == nil {
+		return ""
+	}
+	return cluster.Properties.ProvisioningState
+}
+
+func toClusterStatus(cluster *ManagedCluster) *types.ClusterStatus {
+	switch provisioningState(cluster) {
+	case StateCreating:
+		return &types.ClusterStatus{Phase: types.Provisioning}
+	case StateSucceeded:
+		return &types.ClusterStatus{Phase: types.Provisioned}
+	case StateUpdating:
+		return &types.ClusterStatus{Phase: types.Reconciling}
+	case StateDeleting:
+		return &types.ClusterStatus{Phase: types.Deprovisioning}
+	case StateFailed:
+		return &types.ClusterStatus{Phase: types.Errored}
+	default:
+		return &types.ClusterStatus{Phase: types.Unknown}
+	}
+}
@@ -0,0 +1,185 @@
+package aks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kyma-project/hydroform/provision/internal/operator/native/rest/resttest"
+	"github.com/kyma-project/hydroform/provision/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	clusterPathPrefix = "/subscriptions/fake-subscription/resourceGroups/my-group/providers/Microsoft.ContainerService/managedClusters/"
+	testKubeconfig    = `apiVersion: v1
+kind: Config
+clusters:
+- name: hydro-cluster
+  cluster:
+    server: https://hydro-cluster.hcp.westeurope.azmk8s.io:443
+    certificate-authority-data: Q0E=
+contexts:
+- name: hydro-cluster-admin
+  context:
+    cluster: hydro-cluster
+    user: clusterAdmin
+current-context: hydro-cluster-admin
+users:
+- name: clusterAdmin
+  user:
+    token: secret
+`
+)
+
+// fakeARM is an in-memory implementation of the managed clusters ARM API.
+// Clusters report Creating on the first GET after creation and Succeeded afterwards.
+type fakeARM struct {
+	mu       sync.Mutex
+	clusters map[string]*ManagedCluster
+	created  *ManagedCluster
+}
+
+func newFakeARM() *fakeARM {
+	return &fakeARM{clusters: map[string]*ManagedCluster{}}
+}
+
+func (f *fakeARM) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.URL.Query().Get("api-version") != apiVersion || !strings.HasPrefix(r.URL.Path, clusterPathPrefix) {
+		resttest.WriteError(w, http.StatusBadRequest)
+		return
+	}
+	name, action, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, clusterPathPrefix), "/")
+
+	switch {
+	case r.Method == http.MethodPut:
+		mc := &ManagedCluster{}
+		if err := json.NewDecoder(r.Body).Decode(mc); err != nil {
+			resttest.WriteError(w, http.StatusBadRequest)
+			return
+		}
+		f.created = mc
+		f.clusters[name] = &ManagedCluster{Location: mc.Location, Properties: &ManagedClusterProperties{ProvisioningState: StateCreating}}
+		w.WriteHeader(http.StatusCreated)
+		resttest.WriteJSON(w, mc)
+	case r.Method == http.MethodGet:
+		mc, ok := f.clusters[name]
+		if !ok {
+			resttest.WriteError(w, http.StatusNotFound)
+			return
+		}
+		resp := *mc
+		props := *mc.Properties
+		resp.Properties = &props
+		switch mc.Properties.ProvisioningState {
+		case StateCreating:
+			mc.Properties.ProvisioningState = StateSucceeded
+			mc.Properties.Fqdn = fmt.Sprintf("%s.hcp.westeurope.azmk8s.io", name)
+		case StateDeleting:
+			delete(f.clusters, name)
+		}
+		resttest.WriteJSON(w, &resp)
+	case r.Method == http.MethodPost && action == "listClusterAdminCredential":
+		if _, ok := f.clusters[name]; !ok {
+			resttest.WriteError(w, http.StatusNotFound)
+			return
+		}
+		resttest.WriteJSON(w, &CredentialResults{
+			Kubeconfigs: []CredentialResult{{Name: adminKubeconfigID, Value: []byte(testKubeconfig)}},
+		})
+	case r.Method == http.MethodDelete:
+		mc, ok := f.clusters[name]
+		if !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		mc.Properties.ProvisioningState = StateDeleting
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		resttest.WriteError(w, http.StatusMethodNotAllowed)
+	}
+}
+
+func testConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"cluster_name":       "hydro-cluster",
+		"agent_count":        3,
+		"agent_vm_size":      "Standard_D4_v3",
+		"agent_disk_size":    50,
+		"kubernetes_version": "1.27",
+		"location":           "westeurope",
+		"resource_group":     "my-group",
+		"subscription_id":    "fake-subscription",
+		"tenant_id":          "fake-tenant",
+		"client_id":          "fake-client-id",
+		"client_secret":      "fake-client-secret",
+	}
+}
+
+func TestLifecycle(t *testing.T) {
+	t.Parallel()
+	fake := newFakeARM()
+	srv := resttest.Server(t, fake)
+
+	c := NewClient(srv.Client(), srv.URL, "fake-subscription")
+	cfg := testConfig()
+	resttest.Lifecycle(t, c, cfg, func(info *types.ClusterInfo) {
+		require.Equal(t, "https://hydro-cluster.hcp.westeurope.azmk8s.io", info.Endpoint)
+		require.Equal(t, []byte("CA"), info.CertificateAuthorityData)
+
+		// check the cluster mapping
+		require.Equal(t, "westeurope", fake.created.Location)
+		require.Equal(t, "1.27", fake.created.Properties.KubernetesVersion)
+		require.Equal(t, "fake-client-id", fake.created.Properties.ServicePrincipalProfile.ClientID)
+		require.Len(t, fake.created.Properties.AgentPoolProfiles, 1)
+		require.Equal(t, 3, fake.created.Properties.AgentPoolProfiles[0].Count)
+		require.Equal(t, "Standard_D4_v3", fake.created.Properties.AgentPoolProfiles[0].VMSize)
+		require.Equal(t, 50, fake.created.Properties.AgentPoolProfiles[0].OSDiskSizeGB)
+
+		kubeconfig, err := c.Credentials(context.Background(), resttest.Options(), info, cfg)
+		require.NoError(t, err)
+		require.Equal(t, testKubeconfig, string(kubeconfig))
+	})
+}
+
+func TestCreateFailed(t *testing.T) {
+	t.Parallel()
+	resttest.CreateRejected(t, func(srv *httptest.Server) resttest.Client {
+		return NewClient(srv.Client(), srv.URL, "fake-subscription")
+	}, testConfig(), resttest.WriteError)
+}
+
+func TestCreateFailedState(t *testing.T) {
+	t.Parallel()
+	srv := resttest.Server(t, resttest.Respond(&ManagedCluster{Properties: &ManagedClusterProperties{ProvisioningState: StateFailed}}))
+
+	_, err := NewClient(srv.Client(), srv.URL, "fake-subscription").Create(context.Background(), resttest.Options(), testConfig())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), StateFailed)
+}
+
+func TestClientFromConfig(t *testing.T) {
+	t.Parallel()
+
+	c, err := clientFromConfig(context.Background(), resttest.Options(), testConfig())
+	require.NoError(t, err)
+	require.Equal(t, "fake-subscription", c.subscriptionID)
+
+	cfg := testConfig()
+	delete(cfg, "tenant_id")
+	cfg["client_secret"] = 42
+	_, err = clientFromConfig(context.Background(), resttest.Options(), cfg)
+	require.ErrorContains(t, err, "Provider.CustomConfigurations['client_secret']", "A credential of the wrong type should be reported")
+
+	cfg["client_secret"] = "fake-client-secret"
+	_, err = clientFromConfig(context.Background(), resttest.Options(), cfg)
+	require.ErrorContains(t, err, "Provider.CustomConfigurations['tenant_id'] cannot be empty")
+}
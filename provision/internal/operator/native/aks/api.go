@@ -0,0 +1,69 @@
+package aks
+
+// The types below are a subset of the Azure Resource Manager managed cluster resources.
+// See https://learn.microsoft.com/en-us/rest/api/aks/managed-clusters
+
+// ManagedCluster is an Azure Kubernetes Service cluster.
+type ManagedCluster struct {
+	// Location is the Azure region of the cluster.
+	Location string `json:"location"`
+	// Properties contains the cluster specification and state.
+	Properties *ManagedClusterProperties `json:"properties,omitempty"`
+}
+
+// ManagedClusterProperties contains the specification and state of a managed cluster.
+type ManagedClusterProperties struct {
+	// KubernetesVersion is the Kubernetes version of the cluster.
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// DNSPrefix is used to build the FQDN of the API server.
+	DNSPrefix string `json:"dnsPrefix,omitempty"`
+	// Fqdn is the fully qualified domain name of the API server.
+	Fqdn string `json:"fqdn,omitempty"`
+	// ProvisioningState is the state of the last operation on the cluster.
+	ProvisioningState string `json:"provisioningState,omitempty"`
+	// AgentPoolProfiles are the node pools of the cluster.
+	AgentPoolProfiles []AgentPoolProfile `json:"agentPoolProfiles,omitempty"`
+	// ServicePrincipalProfile is the identity the cluster uses to manipulate Azure APIs.
+	ServicePrincipalProfile *ServicePrincipalProfile `json:"servicePrincipalProfile,omitempty"`
+}
+
+// AgentPoolProfile is a node pool of a managed cluster.
+type AgentPoolProfile struct {
+	// Name is the unique name of the pool within the cluster.
+	Name string `json:"name"`
+	// Count is the number of nodes in the pool.
+	Count int `json:"count"`
+	// VMSize is the size of the virtual machines of the pool.
+	VMSize string `json:"vmSize,omitempty"`
+	// OSDiskSizeGB is the OS disk size of each node in GB.
+	OSDiskSizeGB int `json:"osDiskSizeGB,omitempty"`
+	// Mode is either System or User.
+	Mode string `json:"mode,omitempty"`
+}
+
+// ServicePrincipalProfile contains the service principal used by the cluster.
+type ServicePrincipalProfile struct {
+	ClientID string `json:"clientId"`
+	Secret   string `json:"secret,omitempty"`
+}
+
+// CredentialResults is the response of a list credentials call.
+type CredentialResults struct {
+	Kubeconfigs []CredentialResult `json:"kubeconfigs"`
+}
+
+// CredentialResult is a single kubeconfig of the cluster.
+type CredentialResult struct {
+	Name  string `json:"name"`
+	Value []byte `json:"value"`
+}
+
+// Provisioning state values.
+const (
+	StateCreating  = "Creating"
+	StateUpdating  = "Updating"
+	StateDeleting  = "Deleting"
+	StateSucceeded = "Succeeded"
+	StateFailed    = "Failed"
+	StateCanceled  = "Canceled"
+)
@@ -0,0 +1,77 @@
+package eks
+
+// The types below are a subset of the Amazon EKS API resources.
+// See https://docs.aws.amazon.com/eks/latest/APIReference/API_Operations.html
+
+// Cluster is an Amazon EKS cluster.
+type Cluster struct {
+	// Name is the name of the cluster.
+	Name string `json:"name"`
+	// Version is the Kubernetes version of the cluster.
+	Version string `json:"version,omitempty"`
+	// RoleArn is the IAM role the control plane uses to manage AWS resources.
+	RoleArn string `json:"roleArn,omitempty"`
+	// ResourcesVpcConfig is the VPC configuration of the cluster.
+	ResourcesVpcConfig *VpcConfig `json:"resourcesVpcConfig,omitempty"`
+	// Endpoint is the URL of the Kubernetes API server.
+	Endpoint string `json:"endpoint,omitempty"`
+	// CertificateAuthority contains the certificate authority of the cluster.
+	CertificateAuthority *Certificate `json:"certificateAuthority,omitempty"`
+	// Status is the current status of the cluster.
+	Status string `json:"status,omitempty"`
+}
+
+// VpcConfig contains the network configuration of a cluster.
+type VpcConfig struct {
+	SubnetIds        []string `json:"subnetIds,omitempty"`
+	SecurityGroupIds []string `json:"securityGroupIds,omitempty"`
+}
+
+// Certificate is a base64 encoded certificate.
+type Certificate struct {
+	Data string `json:"data,omitempty"`
+}
+
+// Nodegroup is a group of managed worker nodes.
+type Nodegroup struct {
+	// NodegroupName is the name of the node group.
+	NodegroupName string `json:"nodegroupName"`
+	// ScalingConfig contains the size of the node group.
+	ScalingConfig *ScalingConfig `json:"scalingConfig,omitempty"`
+	// DiskSize is the root volume size of each node in GiB.
+	DiskSize int `json:"diskSize,omitempty"`
+	// InstanceTypes are the EC2 instance types of the nodes.
+	InstanceTypes []string `json:"instanceTypes,omitempty"`
+	// Subnets are the subnets the nodes are started in.
+	Subnets []string `json:"subnets,omitempty"`
+	// NodeRole is the IAM role of the nodes.
+	NodeRole string `json:"nodeRole,omitempty"`
+	// Status is the current status of the node group.
+	Status string `json:"status,omitempty"`
+}
+
+// ScalingConfig contains the autoscaling bounds of a node group.
+type ScalingConfig struct {
+	MinSize     int `json:"minSize"`
+	MaxSize     int `json:"maxSize"`
+	DesiredSize int `json:"desiredSize"`
+}
+
+type clusterResponse struct {
+	Cluster *Cluster `json:"cluster"`
+}
+
+type nodegroupResponse struct {
+	Nodegroup *Nodegroup `json:"nodegroup"`
+}
+
+// Cluster and node group status values.
+const (
+	StatusCreating     = "CREATING"
+	StatusActive       = "ACTIVE"
+	StatusUpdating     = "UPDATING"
+	StatusDeleting     = "DELETING"
+	StatusFailed       = "FAILED"
+	StatusCreateFailed = "CREATE_FAILED"
+	StatusDegraded     = "DEGRADED"
+)
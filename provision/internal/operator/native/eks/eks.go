@@ -0,0 +1,366 @@
+package eks
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kyma-project/hydroform/provision/internal/operator/native/poll"
+	"github.com/kyma-project/hydroform/provision/internal/operator/native/rest"
+	"github.com/kyma-project/hydroform/provision/types"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	endpointTemplate = "https://eks.%s.amazonaws.com"
+	signingService   = "eks"
+)
+
+/*-- EKS native operator --*/
+
+func Create(ctx context.Context, ops *types.Options, cfg map[string]interface{}) (*types.ClusterInfo, error) {
+	return clientFromConfig(ops, cfg).Create(ctx, ops, cfg)
+}
+
+func Status(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) (*types.ClusterStatus, error) {
+	return clientFromConfig(ops, cfg).Status(ctx, ops, info, cfg)
+}
+
+func Credentials(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) ([]byte, error) {
+	return clientFromConfig(ops, cfg).Credentials(ctx, ops, info, cfg)
+}
+
+func Delete(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) error {
+	return clientFromConfig(ops, cfg).Delete(ctx, ops, info, cfg)
+}
+
+/*-- EKS client --*/
+
+// Client performs cluster operations against the Amazon EKS REST API.
+type Client struct {
+	httpClient *http.Client
+	endpoint   string
+}
+
+// NewClient creates an EKS client that sends its requests with the given HTTP client to the given API endpoint.
+// The HTTP client is responsible for signing the requests.
+func NewClient(httpClient *http.Client, endpoint string) *Client {
+	return &Client{
+		httpClient: httpClient,
+		endpoint:   endpoint,
+	}
+}
+
+// NewSigningHTTPClient creates an HTTP client that signs its requests for the EKS API of the given region.
+func NewSigningHTTPClient(creds AccessKeys, region string) *http.Client {
+	return &http.Client{
+		Transport: &signingTransport{
+			credentials: creds,
+			region:      region,
+			service:     signingService,
+			base:        http.DefaultTransport,
+			now:         time.Now,
+		},
+	}
+}
+
+// clientFromConfig creates an EKS client for the region and access keys in the configuration.
+func clientFromConfig(ops *types.Options, cfg map[string]interface{}) *Client {
+	region, _ := cfg["location"].(string)
+	creds := AccessKeys{}
+	creds.AccessKeyID, _ = cfg["access_key_id"].(string)
+	creds.SecretAccessKey, _ = cfg["secret_access_key"].(string)
+	creds.SessionToken, _ = cfg["session_token"].(string)
+
+	return NewClient(rest.WithLogging(NewSigningHTTPClient(creds, region), ops.Log()), fmt.Sprintf(endpointTemplate, region))
+}
+
+// Create creates a new EKS cluster with a managed node group and waits until both are active.
+func (c *Client) Create(ctx context.Context, ops *types.Options, cfg map[string]interface{}) (*types.ClusterInfo, error) {
+	start := time.Now()
+	timeout := ops.Timeout(types.CreateOperation)
+	interval := ops.PollingInterval(types.CreateOperation)
+	name := clusterName(cfg)
+
+	if err := rest.Do(ctx, c.httpClient, http.MethodPost, c.clustersURL(), toCluster(cfg), &clusterResponse{}); err != nil {
+		return &types.ClusterInfo{
+			Status: &types.ClusterStatus{
+				Phase: types.Errored,
+			},
+		}, err
+	}
+
+	var cluster *Cluster
+	err := poll.Until(ctx, ops.Log(), types.CreateOperation, interval, timeout, func(ctx context.Context) (bool, error) {
+		var err error
+		if cluster, err = c.describeCluster(ctx, name); err != nil {
+			return false, err
+		}
+		if cluster.Status == StatusFailed {
+			return false, errors.Errorf("EKS cluster %s failed", name)
+		}
+		return cluster.Status == StatusActive, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ng := toNodegroup(cfg)
+	if err := rest.Do(ctx, c.httpClient, http.MethodPost, c.nodegroupsURL(name), ng, &nodegroupResponse{}); err != nil {
+		return nil, errors.Wrap(err, "could not create the EKS node group")
+	}
+
+	err = poll.Until(ctx, ops.Log(), types.CreateOperation, interval, timeout-time.Since(start), func(ctx context.Context) (bool, error) {
+		n, err := c.describeNodegroup(ctx, name, ng.NodegroupName)
+		if err != nil {
+			return false, err
+		}
+		if n.Status == StatusCreateFailed {
+			return false, errors.Errorf("EKS node group %s failed", ng.NodegroupName)
+		}
+		return n.Status == StatusActive, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toClusterInfo(cluster)
+}
+
+// Status returns the status of an existing EKS cluster.
+func (c *Client) Status(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) (*types.ClusterStatus, error) {
+	cluster, err := c.describeCluster(ctx, clusterName(cfg))
+	if err != nil {
+		return &types.ClusterStatus{
+			Phase: types.Errored,
+		}, err
+	}
+	return toClusterStatus(cluster), nil
+}
+
+// Credentials returns a kubeconfig for an existing EKS cluster.
+// The kubeconfig authenticates with the token generated by the AWS CLI.
+func (c *Client) Credentials(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) ([]byte, error) {
+	name := clusterName(cfg)
+	cluster, err := c.describeCluster(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	clusterInfo, err := toClusterInfo(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	region, _ := cfg["location"].(string)
+	kubeconfig := clientcmdapi.NewConfig()
+	kubeconfig.Clusters[name] = &clientcmdapi.Cluster{
+		Server:                   clusterInfo.Endpoint,
+		CertificateAuthorityData: clusterInfo.CertificateAuthorityData,
+	}
+	kubeconfig.AuthInfos[name] = &clientcmdapi.AuthInfo{
+		Exec: &clientcmdapi.ExecConfig{
+			APIVersion:      "client.authentication.k8s.io/v1beta1",
+			Command:         "aws",
+			Args:            []string{"eks", "get-token", "--cluster-name", name, "--region", region},
+			InteractiveMode: clientcmdapi.NeverExecInteractiveMode,
+		},
+	}
+	kubeconfig.Contexts[name] = &clientcmdapi.Context{
+		Cluster:  name,
+		AuthInfo: name,
+	}
+	kubeconfig.CurrentContext = name
+
+	return clientcmd.Write(*kubeconfig)
+}
+
+// Delete deletes the node group and the EKS cluster and waits until both are gone.
+func (c *Client) Delete(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) error {
+	start := time.Now()
+	timeout := ops.Timeout(types.DeleteOperation)
+	interval := ops.PollingInterval(types.DeleteOperation)
+	name := clusterName(cfg)
+	ngName := nodegroupName(cfg)
+
+	// the cluster can only be deleted after all its node groups are gone
+	err := rest.Do(ctx, c.httpClient, http.MethodDelete, c.nodegroupURL(name, ngName), nil, nil)
+	if err != nil && !rest.IsNotFound(err) {
+		return errors.Wrap(err, "could not delete the EKS node group")
+	}
+	err = poll.Until(ctx, ops.Log(), types.DeleteOperation, interval, timeout, func(ctx context.Context) (bool, error) {
+		_, err := c.describeNodegroup(ctx, name, ngName)
+		if rest.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := rest.Do(ctx, c.httpClient, http.MethodDelete, c.clusterURL(name), nil, nil); err != nil {
+		if rest.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	return poll.Until(ctx, ops.Log(), types.DeleteOperation, interval, timeout-time.Since(start), func(ctx context.Context) (bool, error) {
+		_, err := c.describeCluster(ctx, name)
+		if rest.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+}
+
+func (c *Client) describeCluster(ctx context.Context, name string) (*Cluster, error) {
+	resp := &clusterResponse{}
+	if err := rest.Do(ctx, c.httpClient, http.MethodGet, c.clusterURL(name), nil, resp); err != nil {
+		return nil, err
+	}
+	if resp.Cluster == nil {
+		return nil, errors.Errorf("empty response describing EKS cluster %s", name)
+	}
+	return resp.Cluster, nil
+}
+
+func (c *Client) describeNodegroup(ctx context.Context, cluster, name string) (*Nodegroup, error) {
+	resp := &nodegroupResponse{}
+	if err := rest.Do(ctx, c.httpClient, http.MethodGet, c.nodegroupURL(cluster, name), nil, resp); err != nil {
+		return nil, err
+	}
+	if resp.Nodegroup == nil {
+		return nil, errors.Errorf("empty response describing EKS node group %s", name)
+	}
+	return resp.Nodegroup, nil
+}
+
+func (c *Client) clustersURL() string {
+	return fmt.Sprintf("%s/clusters", c.endpoint)
+}
+
+func (c *Client) clusterURL(name string) string {
+	return fmt.Sprintf("%s/%s", c.clustersURL(), name)
+}
+
+func (c *Client) nodegroupsURL(cluster string) string {
+	return fmt.Sprintf("%s/node-groups", c.clusterURL(cluster))
+}
+
+func (c *Client) nodegroupURL(cluster, name string) string {
+	return fmt.Sprintf("%s/%s", c.nodegroupsURL(cluster), name)
+}
+
+/*-- Cluster building functions --*/
+
+func clusterName(cfg map[string]interface{}) string {
+	name, _ := cfg["cluster_name"].(string)
+	return name
+}
+
+func nodegroupName(cfg map[string]interface{}) string {
+	if v, ok := cfg["nodegroup_name"].(string); ok && len(v) > 0 {
+		return v
+	}
+	return fmt.Sprintf("%s-workers", clusterName(cfg))
+}
+
+func toCluster(cfg map[string]interface{}) *Cluster {
+	cluster := &Cluster{
+		Name:               clusterName(cfg),
+		ResourcesVpcConfig: &VpcConfig{},
+	}
+
+	if v, ok := cfg["kubernetes_version"].(string); ok && len(v) > 0 {
+		cluster.Version = v
+	}
+	if v, ok := cfg["role_arn"].(string); ok && len(v) > 0 {
+		cluster.RoleArn = v
+	}
+	cluster.ResourcesVpcConfig.SubnetIds = stringSlice(cfg["subnet_ids"])
+	cluster.ResourcesVpcConfig.SecurityGroupIds = stringSlice(cfg["security_group_ids"])
+	return cluster
+}
+
+func toNodegroup(cfg map[string]interface{}) *Nodegroup {
+	ng := &Nodegroup{
+		NodegroupName: nodegroupName(cfg),
+		ScalingConfig: &ScalingConfig{},
+		Subnets:       stringSlice(cfg["subnet_ids"]),
+	}
+
+	if v, ok := cfg["node_count"].(int); ok && v > 0 {
+		ng.ScalingConfig.DesiredSize = v
+		ng.ScalingConfig.MinSize = v
+		ng.ScalingConfig.MaxSize = v
+	}
+	if v, ok := cfg["worker_minimum"].(int); ok && v > 0 {
+		ng.ScalingConfig.MinSize = v
+	}
+	if v, ok := cfg["worker_maximum"].(int); ok && v > 0 {
+		ng.ScalingConfig.MaxSize = v
+	}
+	if v, ok := cfg["machine_type"].(string); ok && len(v) > 0 {
+		ng.InstanceTypes = []string{v}
+	}
+	if v, ok := cfg["disk_size"].(int); ok && v > 0 {
+		ng.DiskSize = v
+	}
+	if v, ok := cfg["node_role_arn"].(string); ok && len(v) > 0 {
+		ng.NodeRole = v
+	}
+	return ng
+}
+
+// stringSlice accepts both []string and the []interface{} produced when decoding JSON or YAML.
+func stringSlice(v interface{}) []string {
+	switch val := v.(type) {
+	case []string:
+		return val
+	case []interface{}:
+		var res []string
+		for _, s := range val {
+			if str, ok := s.(string); ok {
+				res = append(res, str)
+			}
+		}
+		return res
+	}
+	return nil
+}
+
+func toClusterInfo(cluster *Cluster) (*types.ClusterInfo, error) {
+	info := &types.ClusterInfo{
+		Endpoint: cluster.Endpoint,
+		Status:   toClusterStatus(cluster),
+	}
+	if cluster.CertificateAuthority != nil && cluster.CertificateAuthority.Data != "" {
+		ca, err := base64.StdEncoding.DecodeString(cluster.CertificateAuthority.Data)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode the cluster CA certificate")
+		}
+		info.CertificateAuthorityData = ca
+	}
+	return info, nil
+}
+
+func toClusterStatus(cluster *Cluster) *types.ClusterStatus {
+	switch cluster.Status {
+	case StatusCreating:
+		return &types.ClusterStatus{Phase: types.Provisioning}
+	case StatusActive:
+		return &types.ClusterStatus{Phase: types.Provisioned}
+	case StatusUpdating:
+		return &types.ClusterStatus{Phase: types.Reconciling}
+	case StatusDeleting:
+		return &types.ClusterStatus{Phase: types.Deprovisioning}
+	case StatusFailed:
+		return &types.ClusterStatus{Phase: types.Errored}
+	default:
+		return &types.ClusterStatus{Phase: types.Unknown}
+	}
+}
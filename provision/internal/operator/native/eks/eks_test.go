@@ -0,0 +1,173 @@
+package eks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kyma-project/hydroform/provision/internal/operator/native/rest/resttest"
+	"github.com/kyma-project/hydroform/provision/types"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// fakeEKS is an in-memory implementation of the EKS clusters and node groups API.
+// Resources report CREATING or DELETING on the first GET and move to their final state afterwards.
+type fakeEKS struct {
+	mu         sync.Mutex
+	clusters   map[string]*Cluster
+	nodegroups map[string]*Nodegroup
+	created    *Cluster
+	createdNG  *Nodegroup
+}
+
+func newFakeEKS() *fakeEKS {
+	return &fakeEKS{
+		clusters:   map[string]*Cluster{},
+		nodegroups: map[string]*Nodegroup{},
+	}
+}
+
+func (f *fakeEKS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if parts[0] != "clusters" {
+		resttest.WriteAWSError(w, http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		c := &Cluster{}
+		if err := json.NewDecoder(r.Body).Decode(c); err != nil {
+			resttest.WriteAWSError(w, http.StatusBadRequest)
+			return
+		}
+		f.created = c
+		f.clusters[c.Name] = &Cluster{Name: c.Name, Status: StatusCreating}
+		resttest.WriteJSON(w, &clusterResponse{Cluster: f.clusters[c.Name]})
+	case len(parts) == 2:
+		c, ok := f.clusters[parts[1]]
+		if !ok {
+			resttest.WriteAWSError(w, http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			c.Status = StatusDeleting
+			resttest.WriteJSON(w, &clusterResponse{Cluster: c})
+			return
+		}
+		resp := *c
+		switch c.Status {
+		case StatusCreating:
+			c.Status = StatusActive
+			c.Endpoint = fmt.Sprintf("https://%s.gr7.eu-west-1.eks.amazonaws.com", c.Name)
+			c.CertificateAuthority = &Certificate{Data: "Q0E="}
+		case StatusDeleting:
+			delete(f.clusters, c.Name)
+		}
+		resttest.WriteJSON(w, &clusterResponse{Cluster: &resp})
+	case len(parts) == 3 && r.Method == http.MethodPost:
+		ng := &Nodegroup{}
+		if err := json.NewDecoder(r.Body).Decode(ng); err != nil {
+			resttest.WriteAWSError(w, http.StatusBadRequest)
+			return
+		}
+		f.createdNG = ng
+		f.nodegroups[ng.NodegroupName] = &Nodegroup{NodegroupName: ng.NodegroupName, Status: StatusCreating}
+		resttest.WriteJSON(w, &nodegroupResponse{Nodegroup: f.nodegroups[ng.NodegroupName]})
+	case len(parts) == 4:
+		ng, ok := f.nodegroups[parts[3]]
+		if !ok {
+			resttest.WriteAWSError(w, http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			ng.Status = StatusDeleting
+			resttest.WriteJSON(w, &nodegroupResponse{Nodegroup: ng})
+			return
+		}
+		resp := *ng
+		switch ng.Status {
+		case StatusCreating:
+			ng.Status = StatusActive
+		case StatusDeleting:
+			delete(f.nodegroups, ng.NodegroupName)
+		}
+		resttest.WriteJSON(w, &nodegroupResponse{Nodegroup: &resp})
+	default:
+		resttest.WriteAWSError(w, http.StatusMethodNotAllowed)
+	}
+}
+
+func testConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"cluster_name":       "hydro-cluster",
+		"node_count":         3,
+		"machine_type":       "m5.xlarge",
+		"disk_size":          50,
+		"kubernetes_version": "1.27",
+		"location":           "eu-west-1",
+		"role_arn":           "arn:aws:iam::123456789012:role/eks-cluster",
+		"node_role_arn":      "arn:aws:iam::123456789012:role/eks-nodes",
+		"subnet_ids":         []interface{}{"subnet-a", "subnet-b"},
+		"access_key_id":      "AKID",
+		"secret_access_key":  "SECRET",
+	}
+}
+
+func TestLifecycle(t *testing.T) {
+	t.Parallel()
+	fake := newFakeEKS()
+	srv := resttest.Server(t, fake)
+
+	c := NewClient(srv.Client(), srv.URL)
+	cfg := testConfig()
+	resttest.Lifecycle(t, c, cfg, func(info *types.ClusterInfo) {
+		require.Equal(t, "https://hydro-cluster.gr7.eu-west-1.eks.amazonaws.com", info.Endpoint)
+		require.Equal(t, []byte("CA"), info.CertificateAuthorityData)
+
+		// check the cluster and node group mapping
+		require.Equal(t, "1.27", fake.created.Version)
+		require.Equal(t, "arn:aws:iam::123456789012:role/eks-cluster", fake.created.RoleArn)
+		require.Equal(t, []string{"subnet-a", "subnet-b"}, fake.created.ResourcesVpcConfig.SubnetIds)
+		require.Equal(t, "hydro-cluster-workers", fake.createdNG.NodegroupName)
+		require.Equal(t, &ScalingConfig{MinSize: 3, MaxSize: 3, DesiredSize: 3}, fake.createdNG.ScalingConfig)
+		require.Equal(t, []string{"m5.xlarge"}, fake.createdNG.InstanceTypes)
+		require.Equal(t, 50, fake.createdNG.DiskSize)
+		require.Equal(t, "arn:aws:iam::123456789012:role/eks-nodes", fake.createdNG.NodeRole)
+
+		kubeconfig, err := c.Credentials(context.Background(), resttest.Options(), info, cfg)
+		require.NoError(t, err)
+		kc, err := clientcmd.Load(kubeconfig)
+		require.NoError(t, err)
+		require.Equal(t, info.Endpoint, kc.Clusters["hydro-cluster"].Server)
+		require.Equal(t, []byte("CA"), kc.Clusters["hydro-cluster"].CertificateAuthorityData)
+		require.Equal(t, "aws", kc.AuthInfos["hydro-cluster"].Exec.Command)
+	})
+	require.Empty(t, fake.clusters)
+	require.Empty(t, fake.nodegroups)
+}
+
+func TestCreateFailed(t *testing.T) {
+	t.Parallel()
+	resttest.CreateRejected(t, func(srv *httptest.Server) resttest.Client {
+		return NewClient(srv.Client(), srv.URL)
+	}, testConfig(), resttest.WriteAWSError)
+}
+
+func TestCreateFailedState(t *testing.T) {
+	t.Parallel()
+	srv := resttest.Server(t, resttest.Respond(&clusterResponse{Cluster: &Cluster{Name: "hydro-cluster", Status: StatusFailed}}))
+
+	_, err := NewClient(srv.Client(), srv.URL).Create(context.Background(), resttest.Options(), testConfig())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed")
+}
@@ -0,0 +1,162 @@
+package eks
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	amzDateFormat    = "20060102T150405Z"
+	shortDateFormat  = "20060102"
+)
+
+// AccessKeys are the AWS access keys used to sign requests.
+type AccessKeys struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// signingTransport signs every request with AWS Signature Version 4 before sending it.
+// See https://docs.aws.amazon.com/IAM/latest/UserGuide/create-signed-request.html
+type signingTransport struct {
+	credentials AccessKeys
+	region      string
+	service     string
+	base        http.RoundTripper
+	now         func() time.Time
+}
+
+func (t *signingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	t.sign(req, body)
+	return t.base.RoundTrip(req)
+}
+
+func (t *signingTransport) sign(req *http.Request, body []byte) {
+	now := t.now().UTC()
+	amzDate := now.Format(amzDateFormat)
+	scope := fmt.Sprintf("%s/%s/%s/aws4_request", now.Format(shortDateFormat), t.region, t.service)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if t.credentials.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", t.credentials.SessionToken)
+	}
+
+	headers, signedHeaders := canonicalHeaders(req)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI(req.URL),
+		canonicalQuery(req.URL),
+		headers,
+		signedHeaders,
+		hexSHA256(body),
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		signingAlgorithm,
+		amzDate,
+		scope,
+		hexSHA256([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+t.credentials.SecretAccessKey), now.Format(shortDateFormat))
+	key = hmacSHA256(key, t.region)
+	key = hmacSHA256(key, t.service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm, t.credentials.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// canonicalHeaders returns the canonical headers block (ending with an empty line) and the list of signed headers.
+// Only the host, the content type and the x-amz-* headers are signed.
+func canonicalHeaders(req *http.Request) (string, string) {
+	values := map[string]string{"host": req.Host}
+	if values["host"] == "" {
+		values["host"] = req.URL.Host
+	}
+	for k := range req.Header {
+		lk := strings.ToLower(k)
+		if lk == "content-type" || strings.HasPrefix(lk, "x-amz-") {
+			values[lk] = strings.Join(strings.Fields(req.Header.Get(k)), " ")
+		}
+	}
+
+	names := make([]string, 0, len(values))
+	for k := range values {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	b := strings.Builder{}
+	for _, n := range names {
+		b.WriteString(fmt.Sprintf("%s:%s\n", n, values[n]))
+	}
+	return b.String(), strings.Join(names, ";")
+}
+
+func canonicalURI(u *url.URL) string {
+	p := u.EscapedPath()
+	if p == "" {
+		return "/"
+	}
+	return p
+}
+
+func canonicalQuery(u *url.URL) string {
+	query := u.Query()
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, k := range keys {
+		vals := query[k]
+		sort.Strings(vals)
+		for _, v := range vals {
+			pairs = append(pairs, fmt.Sprintf("%s=%s", escape(k), escape(v)))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// escape encodes a query component as required by RFC 3986.
+func escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func hexSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,50 @@
+package eks
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestSign uses the get-vanilla and get-vanilla-query-order-key-case cases of the AWS Signature Version 4 test suite.
+func TestSign(t *testing.T) {
+	t.Parallel()
+	tr := &signingTransport{
+		credentials: AccessKeys{
+			AccessKeyID:     "AKIDEXAMPLE",
+			SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		},
+		region:  "us-east-1",
+		service: "service",
+		now: func() time.Time {
+			return time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+		},
+	}
+
+	tests := []struct {
+		url       string
+		signature string
+	}{
+		{
+			url:       "https://example.amazonaws.com/",
+			signature: "5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		},
+		{
+			url:       "https://example.amazonaws.com/?Param2=value2&Param1=value1",
+			signature: "b97d918cfa904a5beff61c982a1b6f458b799221646efd99d3219ec94cdf2500",
+		},
+	}
+
+	for _, tc := range tests {
+		req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+		require.NoError(t, err)
+
+		tr.sign(req, nil)
+
+		require.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+		require.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+			"SignedHeaders=host;x-amz-date, Signature="+tc.signature, req.Header.Get("Authorization"))
+	}
+}
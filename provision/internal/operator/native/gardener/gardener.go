@@ -2,27 +2,39 @@ package gardener
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"log/slog"
+	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	gardenerTypes "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	gardenerApi "github.com/gardener/gardener/pkg/client/core/clientset/versioned/typed/core/v1beta1"
 	"github.com/kyma-project/hydroform/provision/internal/operator/native/gardener/aws"
 	"github.com/kyma-project/hydroform/provision/internal/operator/native/gardener/azure"
 	"github.com/kyma-project/hydroform/provision/internal/operator/native/gardener/gcp"
+	"github.com/kyma-project/hydroform/provision/internal/operator/native/poll"
+	"github.com/kyma-project/hydroform/provision/internal/operator/native/rest"
 
 	"github.com/kyma-project/hydroform/provision/types"
 	"github.com/pkg/errors"
+	k8sCore "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sTypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
 /*-- Gardener native operator --*/
 
-func Create(ops *types.Options, cfg map[string]interface{}) (*types.ClusterInfo, error) {
-	client, err := seedClient(cfg["credentials_file_path"].(string))
+func Create(ctx context.Context, ops *types.Options, cfg map[string]interface{}) (*types.ClusterInfo, error) {
+	client, core, err := seedClients(cfg["credentials_file_path"].(string), ops.Log())
 	if err != nil {
 		return nil, errors.Wrap(err, "error creating the gardener client from credentials")
 	}
@@ -31,88 +43,536 @@ func Create(ops *types.Options, cfg map[string]interface{}) (*types.ClusterInfo,
 		return nil, errors.Wrap(err, "error generating shoot spec from config")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute) // TODO use the timeouts in the Options param
-	defer cancel()
-
-	_, err = client.Shoots(cfg["namespace"].(string)).Create(ctx, shoot, v1.CreateOptions{})
+	progress := newProgressReporter(ops, types.CreateOperation, cfg["cluster_name"].(string))
+	created, err := client.Shoots(cfg["namespace"].(string)).Create(ctx, shoot, v1.CreateOptions{})
 	if err != nil {
+		progress.completed(nil, err)
 		return &types.ClusterInfo{
 			Status: &types.ClusterStatus{
 				Phase: types.Errored,
 			},
 		}, err
 	}
+	progress.started(created)
 
-	if err := waitForShoot(ctx, client, cfg["cluster_name"].(string), cfg["namespace"].(string), 15*time.Second); err != nil {
+	shoot, err = waitForShootOperation(ctx, client, types.CreateOperation, cfg["cluster_name"].(string), cfg["namespace"].(string), 0,
+		ops.PollingInterval(types.CreateOperation), ops.Timeout(types.CreateOperation), nil, progress)
+	progress.completed(shoot, err)
+	if err != nil {
 		return nil, err
 	}
 
-	return &types.ClusterInfo{
-		Status: &types.ClusterStatus{
-			Phase: types.Provisioned,
-		},
-	}, nil
+	info := &types.ClusterInfo{
+		Status: shootStatus(shoot),
+	}
+	fillClusterInfo(ctx, progress.log(), core, cfg["namespace"].(string), shoot, info)
+	return info, nil
 }
 
-func Status(ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) (*types.ClusterStatus, error) {
-	client, err := seedClient(cfg["credentials_file_path"].(string))
+// Status returns the status of the shoot.
+// If info is not nil, its endpoint, CA and shoot identifiers are refreshed from the shoot as well.
+func Status(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) (*types.ClusterStatus, error) {
+	client, core, err := seedClients(cfg["credentials_file_path"].(string), ops.Log())
 	if err != nil {
 		return nil, errors.Wrap(err, "error creating the gardener client from credentials")
 	}
-	_, err = client.Shoots(cfg["namespace"].(string)).Get(context.TODO(), cfg["cluster_name"].(string), v1.GetOptions{})
+	shoot, err := client.Shoots(cfg["namespace"].(string)).Get(ctx, cfg["cluster_name"].(string), v1.GetOptions{})
 	if err != nil {
 		return &types.ClusterStatus{
 			Phase: types.Errored,
 		}, err
 	}
-	return &types.ClusterStatus{
-		Phase: types.Provisioned,
-	}, nil
+	if info != nil {
+		fillClusterInfo(ctx, ops.Log().With("cluster", shoot.Name), core, cfg["namespace"].(string), shoot, info)
+	}
+	return shootStatus(shoot), nil
+}
+
+// Update patches the Kubernetes version, the workers and the hibernation schedules of an existing shoot
+// and waits until Gardener reconciled the change.
+func Update(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) (*types.ClusterInfo, error) {
+	client, core, err := seedClients(cfg["credentials_file_path"].(string), ops.Log())
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the gardener client from credentials")
+	}
+	desired, err := toShoot(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "error generating shoot spec from config")
+	}
+
+	name := cfg["cluster_name"].(string)
+	namespace := cfg["namespace"].(string)
+	current, err := client.Shoots(namespace).Get(ctx, name, v1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get the shoot to update")
+	}
+
+	patch, err := shootPatch(current, desired)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute the shoot patch")
+	}
+
+	shoot := current
+	if patch != nil {
+		progress := newProgressReporter(ops, types.UpdateOperation, name)
+		shoot, err = client.Shoots(namespace).Patch(ctx, name, k8sTypes.StrategicMergePatchType, patch, v1.PatchOptions{})
+		if err != nil {
+			err = errors.Wrap(err, "could not patch the shoot")
+			progress.completed(nil, err)
+			return nil, err
+		}
+		progress.started(shoot)
+
+		shoot, err = waitForShootOperation(ctx, client, types.UpdateOperation, name, namespace, shoot.Generation,
+			ops.PollingInterval(types.UpdateOperation), ops.Timeout(types.UpdateOperation), nil, progress)
+		progress.completed(shoot, err)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if info == nil {
+		info = &types.ClusterInfo{}
+	}
+	info.Status = shootStatus(shoot)
+	fillClusterInfo(ctx, ops.Log().With("cluster", name), core, namespace, shoot, info)
+	return info, nil
+}
+
+// Hibernate enables the hibernation of an existing shoot and waits until it is hibernated.
+func Hibernate(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) (*types.ClusterInfo, error) {
+	return setHibernation(ctx, ops, info, cfg, true)
+}
+
+// WakeUp disables the hibernation of an existing shoot and waits until it is running again.
+func WakeUp(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) (*types.ClusterInfo, error) {
+	return setHibernation(ctx, ops, info, cfg, false)
 }
 
-func Delete(ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) error {
-	client, err := seedClient(cfg["credentials_file_path"].(string))
+func setHibernation(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}, enabled bool) (*types.ClusterInfo, error) {
+	client, core, err := seedClients(cfg["credentials_file_path"].(string), ops.Log())
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the gardener client from credentials")
+	}
+
+	name := cfg["cluster_name"].(string)
+	namespace := cfg["namespace"].(string)
+	op := types.WakeUpOperation
+	if enabled {
+		op = types.HibernateOperation
+	}
+	progress := newProgressReporter(ops, op, name)
+
+	patch := []byte(fmt.Sprintf(`{"spec":{"hibernation":{"enabled":%t}}}`, enabled))
+	shoot, err := client.Shoots(namespace).Patch(ctx, name, k8sTypes.MergePatchType, patch, v1.PatchOptions{})
+	if err != nil {
+		err = errors.Wrap(err, "could not patch the shoot hibernation")
+		progress.completed(nil, err)
+		return nil, err
+	}
+	progress.started(shoot)
+
+	shoot, err = waitForShootOperation(ctx, client, op, name, namespace, shoot.Generation,
+		ops.PollingInterval(op), ops.Timeout(op), hibernated(enabled), progress)
+	progress.completed(shoot, err)
+	if err != nil {
+		return nil, err
+	}
+
+	if info == nil {
+		info = &types.ClusterInfo{}
+	}
+	info.Status = shootStatus(shoot)
+	fillClusterInfo(ctx, ops.Log().With("cluster", name), core, namespace, shoot, info)
+	return info, nil
+}
+
+// hibernated returns a check whether the hibernation state of a shoot matches the given one.
+func hibernated(enabled bool) func(*gardenerTypes.Shoot) bool {
+	return func(sh *gardenerTypes.Shoot) bool {
+		return sh.Status.IsHibernated == enabled
+	}
+}
+
+// Delete confirms the deletion of the shoot with the annotation Gardener requires, deletes it, and waits until it is gone.
+// A shoot that does not exist is considered deleted. If the deletion fails or times out, the last errors reported by Gardener are returned.
+func Delete(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) error {
+	client, _, err := seedClients(cfg["credentials_file_path"].(string), ops.Log())
 	if err != nil {
 		return errors.Wrap(err, "error creating the gardener client from credentials")
 	}
 
-	return client.Shoots(cfg["namespace"].(string)).Delete(context.TODO(), cfg["cluster_name"].(string), v1.DeleteOptions{})
+	name := cfg["cluster_name"].(string)
+	namespace := cfg["namespace"].(string)
+	progress := newProgressReporter(ops, types.DeleteOperation, name)
+	err = deleteShoot(ctx, client, name, namespace, ops.PollingInterval(types.DeleteOperation), ops.Timeout(types.DeleteOperation), progress)
+	progress.completed(nil, err)
+	return err
 }
 
-func waitForShoot(ctx context.Context, getter gardenerApi.ShootsGetter, name, namespace string, pollingInterval time.Duration) error {
+// deleteShoot annotates, deletes and waits for the deletion of the shoot, reporting its progress to the reporter, which can be nil.
+func deleteShoot(ctx context.Context, getter gardenerApi.ShootsGetter, name, namespace string, pollingInterval, timeout time.Duration,
+	progress *progressReporter) error {
+	patch := []byte(fmt.Sprintf(`{"metadata":{"annotations":{%q:"true"}}}`, confirmationDeletion))
+	shoot, err := getter.Shoots(namespace).Patch(ctx, name, k8sTypes.MergePatchType, patch, v1.PatchOptions{})
+	if k8sErrors.IsNotFound(err) {
+		progress.log().Info("shoot already deleted", "namespace", namespace)
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not confirm the deletion of the shoot")
+	}
+
+	err = getter.Shoots(namespace).Delete(ctx, name, v1.DeleteOptions{})
+	if k8sErrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not delete the shoot")
+	}
+	progress.started(shoot)
 
-	timer := time.NewTicker(pollingInterval)
+	return waitForShootDeletion(ctx, getter, name, namespace, pollingInterval, timeout, progress)
+}
 
-	for {
-		select {
-		case <-timer.C:
-			sh, err := getter.Shoots(namespace).Get(context.Background(), name, v1.GetOptions{})
-			if err != nil {
-				return err
-			}
+// waitForShootDeletion polls the shoot until it is gone. It fails early if Gardener reports that the deletion failed.
+// The last errors Gardener reported for the shoot are added to the error.
+func waitForShootDeletion(ctx context.Context, getter gardenerApi.ShootsGetter, name, namespace string, pollingInterval, timeout time.Duration,
+	progress *progressReporter) error {
+	var shoot *gardenerTypes.Shoot
+	err := poll.Until(ctx, progress.log(), types.DeleteOperation, pollingInterval, timeout, func(ctx context.Context) (bool, error) {
+		sh, err := getter.Shoots(namespace).Get(ctx, name, v1.GetOptions{})
+		if k8sErrors.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		shoot = sh
+		progress.observe(sh)
+		if op := sh.Status.LastOperation; op != nil && op.Type == gardenerTypes.LastOperationTypeDelete &&
+			op.State == gardenerTypes.LastOperationStateFailed {
+			return false, errors.Errorf("deletion of shoot %s failed: %s", name, op.Description)
+		}
+		return false, nil
+	})
+	if err != nil && shoot != nil && len(shoot.Status.LastErrors) > 0 {
+		var lastErrors []string
+		for _, le := range shoot.Status.LastErrors {
+			lastErrors = append(lastErrors, le.Description)
+		}
+		return fmt.Errorf("%w, last errors: %s", err, strings.Join(lastErrors, "; "))
+	}
+	return err
+}
 
-			if sh.Status.LastOperation != nil && sh.Status.LastOperation.Progress == 100 && sh.Status.LastOperation.State == gardenerTypes.LastOperationStateSucceeded {
-				return nil
+// waitForShoot polls the shoot until its last operation succeeded or the timeout expires and returns the final shoot.
+func waitForShoot(ctx context.Context, getter gardenerApi.ShootsGetter, name, namespace string, pollingInterval, timeout time.Duration) (*gardenerTypes.Shoot, error) {
+	return waitForShootOperation(ctx, getter, types.CreateOperation, name, namespace, 0, pollingInterval, timeout, nil, nil)
+}
+
+// waitForShootOperation polls the shoot until Gardener observed at least the given generation and its last operation succeeded.
+// If done is not nil, the shoot must also satisfy it. It fails early if the last operation failed permanently.
+// The changes of the shoot are passed to the progress reporter, which can be nil.
+func waitForShootOperation(ctx context.Context, getter gardenerApi.ShootsGetter, op types.Operation, name, namespace string,
+	generation int64, pollingInterval, timeout time.Duration, done func(*gardenerTypes.Shoot) bool, progress *progressReporter) (*gardenerTypes.Shoot, error) {
+	var shoot *gardenerTypes.Shoot
+	err := poll.Until(ctx, progress.log(), op, pollingInterval, timeout, func(ctx context.Context) (bool, error) {
+		sh, err := getter.Shoots(namespace).Get(ctx, name, v1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		shoot = sh
+		if sh.Status.ObservedGeneration < generation || sh.Status.LastOperation == nil {
+			return false, nil
+		}
+		progress.observe(sh)
+		if sh.Status.LastOperation.State == gardenerTypes.LastOperationStateFailed {
+			return false, errors.Errorf("shoot %s failed: %s", name, sh.Status.LastOperation.Description)
+		}
+		if done != nil && !done(sh) {
+			return false, nil
+		}
+		return sh.Status.LastOperation.Progress == 100 && sh.Status.LastOperation.State == gardenerTypes.LastOperationStateSucceeded, nil
+	})
+	return shoot, err
+}
+
+/*-- Shoot update --*/
+
+// shootPatch returns a strategic merge patch that applies the updatable fields of the desired shoot to the current one,
+// or nil if there is nothing to change.
+func shootPatch(current, desired *gardenerTypes.Shoot) ([]byte, error) {
+	updated := current.DeepCopy()
+	applyUpdate(updated, desired)
+
+	currentJSON, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+	updatedJSON, err := json.Marshal(updated)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := strategicpatch.CreateTwoWayMergePatch(currentJSON, updatedJSON, gardenerTypes.Shoot{})
+	if err != nil {
+		return nil, err
+	}
+	if string(patch) == "{}" {
+		return nil, nil
+	}
+	return patch, nil
+}
+
+// applyUpdate copies the Kubernetes version, the worker scaling and machine settings and the hibernation schedules
+// from the desired shoot. Workers are matched by name; workers that do not exist yet are added,
+// and workers that are no longer desired are removed.
+func applyUpdate(shoot, desired *gardenerTypes.Shoot) {
+	if desired.Spec.Kubernetes.Version != "" {
+		shoot.Spec.Kubernetes.Version = desired.Spec.Kubernetes.Version
+	}
+
+	workers := make([]gardenerTypes.Worker, 0, len(desired.Spec.Provider.Workers))
+	for _, w := range shoot.Spec.Provider.Workers {
+		for _, dw := range desired.Spec.Provider.Workers {
+			if w.Name != dw.Name {
+				continue
+			}
+			w.Minimum = dw.Minimum
+			w.Maximum = dw.Maximum
+			if dw.Machine.Type != "" {
+				w.Machine.Type = dw.Machine.Type
 			}
-		case <-ctx.Done():
-			return errors.New("Provisioning timed out")
+			w.Machine.Image = updatedImage(w.Machine.Image, dw.Machine.Image)
+			workers = append(workers, w)
+		}
+	}
+	for _, dw := range desired.Spec.Provider.Workers {
+		if !hasWorker(shoot, dw.Name) {
+			workers = append(workers, dw)
 		}
 	}
+	shoot.Spec.Provider.Workers = workers
+
+	if desired.Spec.Hibernation != nil {
+		if shoot.Spec.Hibernation == nil {
+			shoot.Spec.Hibernation = &gardenerTypes.Hibernation{}
+		}
+		shoot.Spec.Hibernation.Schedules = desired.Spec.Hibernation.Schedules
+	}
+}
+
+// updatedImage returns the image of a worker with the name and the version of the desired image.
+// A version that is not configured is left to the running one, so that Gardener does not pick a different image version.
+func updatedImage(image, desired *gardenerTypes.ShootMachineImage) *gardenerTypes.ShootMachineImage {
+	if desired == nil || (desired.Name == "" && desired.Version == nil) {
+		return image
+	}
+	if image == nil {
+		image = &gardenerTypes.ShootMachineImage{}
+	} else {
+		image = image.DeepCopy()
+	}
+	if desired.Name != "" {
+		image.Name = desired.Name
+	}
+	if desired.Version != nil {
+		v := *desired.Version
+		image.Version = &v
+	}
+	return image
+}
+
+// hasWorker checks if the shoot has a worker with the given name.
+func hasWorker(shoot *gardenerTypes.Shoot, name string) bool {
+	for _, w := range shoot.Spec.Provider.Workers {
+		if w.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+/*-- Cluster status --*/
+
+// healthConditions are the shoot conditions reported in the cluster status.
+var healthConditions = map[gardenerTypes.ConditionType]types.ConditionType{
+	gardenerTypes.ShootAPIServerAvailable:      types.APIServerAvailable,
+	gardenerTypes.ShootControlPlaneHealthy:     types.ControlPlaneHealthy,
+	gardenerTypes.ShootEveryNodeReady:          types.EveryNodeReady,
+	gardenerTypes.ShootSystemComponentsHealthy: types.SystemComponentsHealthy,
+}
+
+// shootStatus maps the status of a shoot to the cluster status.
+func shootStatus(shoot *gardenerTypes.Shoot) *types.ClusterStatus {
+	status := &types.ClusterStatus{
+		Phase: shootPhase(shoot),
+	}
+
+	if op := shoot.Status.LastOperation; op != nil {
+		status.Progress = int(op.Progress)
+		status.Description = op.Description
+	}
+
+	for _, c := range shoot.Status.Conditions {
+		t, ok := healthConditions[c.Type]
+		if !ok {
+			continue
+		}
+		status.Conditions = append(status.Conditions, types.Condition{
+			Type:               t,
+			Status:             types.ConditionStatus(c.Status),
+			Reason:             c.Reason,
+			Message:            c.Message,
+			LastTransitionTime: c.LastTransitionTime.Time,
+		})
+	}
+	return status
+}
+
+func shootPhase(shoot *gardenerTypes.Shoot) types.Phase {
+	op := shoot.Status.LastOperation
+	if op == nil {
+		if shoot.DeletionTimestamp != nil {
+			return types.Deprovisioning
+		}
+		// the shoot has not been picked up by Gardener yet
+		return types.Provisioning
+	}
+
+	switch op.State {
+	case gardenerTypes.LastOperationStateFailed:
+		return types.Failed
+	case gardenerTypes.LastOperationStateError:
+		return types.Errored
+	}
+
+	if shoot.DeletionTimestamp != nil || op.Type == gardenerTypes.LastOperationTypeDelete {
+		return types.Deprovisioning
+	}
+	if op.State == gardenerTypes.LastOperationStateSucceeded {
+		if shoot.Status.IsHibernated {
+			return types.Hibernated
+		}
+		return types.Provisioned
+	}
+	if op.Type == gardenerTypes.LastOperationTypeCreate {
+		return types.Provisioning
+	}
+	return types.Reconciling
+}
+
+/*-- Cluster info --*/
+
+const (
+	// caClusterSuffix is the suffix of the ConfigMap and Secret in which Gardener publishes the cluster CA of a shoot
+	// in the project namespace.
+	caClusterSuffix = "ca-cluster"
+	caCertKey       = "ca.crt"
+	// externalAddress is the name of the advertised address of the public API server domain.
+	externalAddress = "external"
+	// confirmationDeletion is the annotation Gardener requires on a shoot before it can be deleted.
+	confirmationDeletion = "confirmation.gardener.cloud/deletion"
+)
+
+// fillClusterInfo sets the endpoint, the CA and the shoot identifiers of the given info from the shoot.
+// A CA that cannot be read is logged as a warning and left empty.
+func fillClusterInfo(ctx context.Context, log *slog.Logger, core corev1.CoreV1Interface, namespace string, shoot *gardenerTypes.Shoot, info *types.ClusterInfo) {
+	shootInfo(shoot, info)
+
+	// the shoot is usable without its CA, for example if the credentials may not read it, so the CA is left empty
+	ca, err := shootCA(ctx, core, namespace, shoot.Name)
+	if err != nil {
+		log.Warn("could not fetch the cluster CA of the shoot", "error", err)
+	}
+	info.CertificateAuthorityData = ca
+}
+
+// shootInfo sets the endpoint, the shoot identifiers and the metadata of the given info from the shoot. It does not need any API call.
+func shootInfo(shoot *gardenerTypes.Shoot, info *types.ClusterInfo) {
+	info.Endpoint = shootEndpoint(shoot)
+	info.UID = string(shoot.Status.UID)
+	if info.UID == "" {
+		info.UID = string(shoot.UID)
+	}
+	info.TechnicalID = shoot.Status.TechnicalID
+	if shoot.Status.SeedName != nil {
+		info.SeedName = *shoot.Status.SeedName
+	} else if shoot.Spec.SeedName != nil {
+		info.SeedName = *shoot.Spec.SeedName
+	}
+	if !shoot.CreationTimestamp.IsZero() {
+		created := shoot.CreationTimestamp.Time
+		info.CreatedAt = &created
+	}
+	info.CreatedBy = shoot.Annotations[v1beta1constants.GardenCreatedBy]
+	info.Labels = shoot.Labels
+}
+
+// shootEndpoint returns the external API server address of the shoot, or the first advertised address if there is no external one.
+func shootEndpoint(shoot *gardenerTypes.Shoot) string {
+	for _, a := range shoot.Status.AdvertisedAddresses {
+		if a.Name == externalAddress {
+			return a.URL
+		}
+	}
+	if len(shoot.Status.AdvertisedAddresses) > 0 {
+		return shoot.Status.AdvertisedAddresses[0].URL
+	}
+	return ""
+}
+
+// shootCA reads the cluster CA from the <shoot>.ca-cluster ConfigMap and falls back to the Secret of the same name
+// used by older Gardener versions. No CA is returned if neither holds one yet.
+func shootCA(ctx context.Context, core corev1.CoreV1Interface, namespace, shootName string) ([]byte, error) {
+	name := fmt.Sprintf("%s.%s", shootName, caClusterSuffix)
+
+	cm, err := core.ConfigMaps(namespace).Get(ctx, name, v1.GetOptions{})
+	if err == nil && cm.Data[caCertKey] != "" {
+		return []byte(cm.Data[caCertKey]), nil
+	}
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	s, err := core.Secrets(namespace).Get(ctx, name, v1.GetOptions{})
+	if err == nil && len(s.Data[caCertKey]) > 0 {
+		return s.Data[caCertKey], nil
+	}
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return nil, err
+	}
+	return nil, nil
 }
 
 /*-- Gardener client --*/
 
-func seedClient(credentialsFile string) (*gardenerApi.CoreV1beta1Client, error) {
+// seedClients creates the Gardener and the Kubernetes core clients for the garden cluster in the kubeconfig file.
+// The API calls of the clients are logged with the given logger.
+func seedClients(credentialsFile string, log *slog.Logger) (*gardenerApi.CoreV1beta1Client, corev1.CoreV1Interface, error) {
 	kubeBytes, err := os.ReadFile(credentialsFile)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	config, err := clientcmd.RESTConfigFromKubeConfig(kubeBytes)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return rest.LoggingTransport(rt, log)
+	})
+	client, err := gardenerApi.NewForConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	core, err := corev1.NewForConfig(config)
+	if err != nil {
+		return nil, nil, err
 	}
-	return gardenerApi.NewForConfig(config)
+	return client, core, nil
 }
 
 /*-- Shoot building functions --*/
@@ -141,6 +601,9 @@ func shootMeta(cfg map[string]interface{}) v1.ObjectMeta {
 	if v, ok := cfg["annotations"].(map[string]string); ok && len(v) > 0 {
 		o.Annotations = v
 	}
+	if v, ok := cfg["labels"].(map[string]string); ok && len(v) > 0 {
+		o.Labels = v
+	}
 
 	return o
 }
@@ -161,7 +624,8 @@ func shootSpec(cfg map[string]interface{}) gardenerTypes.ShootSpec {
 		o.Region = v
 	}
 	if v, ok := cfg["purpose"].(string); ok && len(v) > 0 {
-		*o.Purpose = gardenerTypes.ShootPurpose(v)
+		purpose := gardenerTypes.ShootPurpose(v)
+		o.Purpose = &purpose
 	}
 
 	o.Kubernetes = shootK8s(cfg)
@@ -248,22 +712,24 @@ func boolPointer(b bool) *bool {
 	return &b
 }
 
+// shootHibernation returns the hibernation schedule of the configuration, or nil if none is configured,
+// so that updates leave the schedules of the shoot as they are.
 func shootHibernation(cfg map[string]interface{}) *gardenerTypes.Hibernation {
-	h := gardenerTypes.Hibernation{}
-
-	if v, ok := cfg["hibernation_start"].(string); ok && len(v) > 0 {
-		h.Schedules = append(h.Schedules, gardenerTypes.HibernationSchedule{
-			Start: &v,
-		})
-		if v, ok := cfg["hibernation_end"].(string); ok && len(v) > 0 {
-			h.Schedules[0].End = &v
-		}
-		if v, ok := cfg["hibernation_location"].(string); ok && len(v) > 0 {
-			h.Schedules[0].Location = &v
-		}
+	v, ok := cfg["hibernation_start"].(string)
+	if !ok || len(v) == 0 {
+		return nil
 	}
 
-	return &h
+	schedule := gardenerTypes.HibernationSchedule{
+		Start: &v,
+	}
+	if v, ok := cfg["hibernation_end"].(string); ok && len(v) > 0 {
+		schedule.End = &v
+	}
+	if v, ok := cfg["hibernation_location"].(string); ok && len(v) > 0 {
+		schedule.Location = &v
+	}
+	return &gardenerTypes.Hibernation{Schedules: []gardenerTypes.HibernationSchedule{schedule}}
 }
 
 // injectProvider adds the provider config to the given shoot.
@@ -299,7 +765,13 @@ func injectProvider(spec *gardenerTypes.ShootSpec, cfg map[string]interface{}) e
 		}
 	}
 
-	p.Workers = append(p.Workers, shootWorker(cfg))
+	if pools, ok := cfg["worker_pools"].([]types.WorkerPool); ok && len(pools) > 0 {
+		for _, pool := range pools {
+			p.Workers = append(p.Workers, poolWorker(cfg, pool))
+		}
+	} else {
+		p.Workers = append(p.Workers, shootWorker(cfg))
+	}
 
 	spec.Provider = p
 	return nil
@@ -348,3 +820,55 @@ func shootWorker(cfg map[string]interface{}) gardenerTypes.Worker {
 	}
 	return w
 }
+
+// poolWorker renders a worker for the given pool.
+// Settings the pool does not specify are taken from the single-pool configuration used by shootWorker.
+func poolWorker(cfg map[string]interface{}, pool types.WorkerPool) gardenerTypes.Worker {
+	w := shootWorker(cfg)
+	w.Name = pool.Name
+	w.Minimum = int32(pool.Minimum)
+	w.Maximum = int32(pool.Maximum)
+
+	if pool.MachineType != "" {
+		w.Machine.Type = pool.MachineType
+	}
+	// the pool keeps the inherited image version unless it sets its own
+	if pool.MachineImageName != "" {
+		w.Machine.Image.Name = pool.MachineImageName
+	}
+	if pool.MachineImageVersion != "" {
+		v := pool.MachineImageVersion
+		w.Machine.Image.Version = &v
+	}
+	if len(pool.Zones) > 0 {
+		w.Zones = pool.Zones
+	}
+	if pool.MaxSurge != nil {
+		i := intstr.FromInt(*pool.MaxSurge)
+		w.MaxSurge = &i
+	}
+	if pool.MaxUnavailable != nil {
+		i := intstr.FromInt(*pool.MaxUnavailable)
+		w.MaxUnavailable = &i
+	}
+	if len(pool.Labels) > 0 {
+		w.Labels = pool.Labels
+	}
+	for _, t := range pool.Taints {
+		w.Taints = append(w.Taints, k8sCore.Taint{
+			Key:    t.Key,
+			Value:  t.Value,
+			Effect: k8sCore.TaintEffect(t.Effect),
+		})
+	}
+	if pool.Volume != nil {
+		if pool.Volume.SizeGB > 0 {
+			w.Volume.VolumeSize = fmt.Sprintf("%dGi", pool.Volume.SizeGB)
+		}
+		if pool.Volume.Type != "" {
+			t := pool.Volume.Type
+			w.Volume.Type = &t
+		}
+	}
+	return w
+}
@@ -2,14 +2,23 @@ package gardener
 
 import (
 	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"sync"
 	"testing"
 	"time"
 
 	gardenerTypes "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	gardenerFake "github.com/gardener/gardener/pkg/client/core/clientset/versioned/typed/core/v1beta1/fake"
+	"github.com/kyma-project/hydroform/provision/types"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	k8sFake "k8s.io/client-go/kubernetes/fake"
 	k8sTesting "k8s.io/client-go/testing"
 )
 
@@ -36,7 +45,9 @@ func TestWaitForShoot(t *testing.T) {
 			shootObject: stubForShootWithoutLastOperation(),
 			assertErr: func(t *testing.T, err error) {
 				require.Error(t, err)
-				require.Equal(t, "Provisioning timed out", err.Error())
+				var timeoutErr *types.TimeoutError
+				require.ErrorAs(t, err, &timeoutErr)
+				require.Equal(t, types.CreateOperation, timeoutErr.Operation)
 			},
 		},
 	}
@@ -56,14 +67,12 @@ func TestWaitForShoot(t *testing.T) {
 					f := &k8sTesting.Fake{}
 					f.AddReactor("get", "shoots", reactor)
 
-					ctx, cancelFunc := context.WithTimeout(context.Background(), 30*time.Millisecond)
-					defer cancelFunc()
 					fakeShootsGetter := gardenerFake.FakeCoreV1beta1{
 						Fake: f,
 					}
 
 					//when
-					err := waitForShoot(ctx, &fakeShootsGetter, testShootsName, testNamespace, 3*time.Millisecond)
+					_, err := waitForShoot(context.Background(), &fakeShootsGetter, testShootsName, testNamespace, 3*time.Millisecond, 30*time.Millisecond)
 
 					//then
 					if tcase.assertErr != nil {
@@ -74,6 +83,26 @@ func TestWaitForShoot(t *testing.T) {
 	}
 }
 
+func TestWaitForShootCancelled(t *testing.T) {
+	t.Parallel()
+	//given
+	f := &k8sTesting.Fake{}
+	f.AddReactor("get", "shoots", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		getAction := action.(k8sTesting.GetActionImpl)
+		return true, stubForShootWithoutLastOperation()(getAction.Name, getAction.Namespace), nil
+	})
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancelFunc)
+
+	//when
+	_, err := waitForShoot(ctx, &gardenerFake.FakeCoreV1beta1{Fake: f}, "someCluster", "someNamespace", 3*time.Millisecond, time.Minute)
+
+	//then
+	require.Error(t, err)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
 func stubForShootWithLastOperation(progress int32, state gardenerTypes.LastOperationState) func(name, namespace string) *gardenerTypes.Shoot {
 
 	return func(name, namespace string) *gardenerTypes.Shoot {
@@ -100,3 +129,604 @@ func stubForShootWithoutLastOperation() func(name, namespace string) *gardenerTy
 	}
 
 }
+
+func TestFillClusterInfo(t *testing.T) {
+	t.Parallel()
+
+	seed := "aws-eu1"
+	shoot := &gardenerTypes.Shoot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "hydro",
+			Namespace: "garden-project",
+		},
+		Status: gardenerTypes.ShootStatus{
+			AdvertisedAddresses: []gardenerTypes.ShootAdvertisedAddress{
+				{Name: "internal", URL: "https://api.hydro.internal.example.com"},
+				{Name: "external", URL: "https://api.hydro.project.example.com"},
+			},
+			SeedName:    &seed,
+			TechnicalID: "shoot--project--hydro",
+			UID:         "1234-5678",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		objects   []runtime.Object
+		forbidden bool
+		ca        []byte
+	}{
+		{
+			name: "CA from ConfigMap",
+			objects: []runtime.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "hydro.ca-cluster", Namespace: "garden-project"},
+				Data:       map[string]string{"ca.crt": "configmap-ca"},
+			}},
+			ca: []byte("configmap-ca"),
+		},
+		{
+			name: "CA from Secret",
+			objects: []runtime.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "hydro.ca-cluster", Namespace: "garden-project"},
+				Data:       map[string][]byte{"ca.crt": []byte("secret-ca")},
+			}},
+			ca: []byte("secret-ca"),
+		},
+		{
+			name: "CA not in the ConfigMap yet",
+			objects: []runtime.Object{
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "hydro.ca-cluster", Namespace: "garden-project"}},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "hydro.ca-cluster", Namespace: "garden-project"},
+					Data:       map[string][]byte{"ca.crt": []byte("secret-ca")},
+				},
+			},
+			ca: []byte("secret-ca"),
+		},
+		{
+			name: "CA not published yet",
+			objects: []runtime.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "hydro.ca-cluster", Namespace: "garden-project"},
+				Data:       map[string]string{"ca.crt": ""},
+			}},
+		},
+		{
+			name:      "CA not readable",
+			forbidden: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			client := k8sFake.NewSimpleClientset(tc.objects...)
+			if tc.forbidden {
+				client.PrependReactor("get", "configmaps", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+					return true, nil, k8sErrors.NewForbidden(corev1.Resource("configmaps"), "hydro.ca-cluster", nil)
+				})
+			}
+
+			info := &types.ClusterInfo{}
+			fillClusterInfo(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), client.CoreV1(), "garden-project", shoot, info)
+
+			require.Equal(t, "https://api.hydro.project.example.com", info.Endpoint)
+			if tc.ca == nil {
+				require.Nil(t, info.CertificateAuthorityData)
+			} else {
+				require.Equal(t, tc.ca, info.CertificateAuthorityData)
+			}
+			require.Equal(t, "1234-5678", info.UID)
+			require.Equal(t, "aws-eu1", info.SeedName)
+			require.Equal(t, "shoot--project--hydro", info.TechnicalID)
+		})
+	}
+}
+
+func TestShootStatus(t *testing.T) {
+	t.Parallel()
+
+	lastOperation := func(opType gardenerTypes.LastOperationType, state gardenerTypes.LastOperationState) *gardenerTypes.LastOperation {
+		return &gardenerTypes.LastOperation{Type: opType, State: state, Progress: 42, Description: "some description"}
+	}
+	now := metav1.Now()
+
+	tests := []struct {
+		name  string
+		shoot *gardenerTypes.Shoot
+		phase types.Phase
+	}{
+		{
+			name:  "Not picked up yet",
+			shoot: &gardenerTypes.Shoot{},
+			phase: types.Provisioning,
+		},
+		{
+			name:  "Creating",
+			shoot: &gardenerTypes.Shoot{Status: gardenerTypes.ShootStatus{LastOperation: lastOperation(gardenerTypes.LastOperationTypeCreate, gardenerTypes.LastOperationStateProcessing)}},
+			phase: types.Provisioning,
+		},
+		{
+			name:  "Created",
+			shoot: &gardenerTypes.Shoot{Status: gardenerTypes.ShootStatus{LastOperation: lastOperation(gardenerTypes.LastOperationTypeCreate, gardenerTypes.LastOperationStateSucceeded)}},
+			phase: types.Provisioned,
+		},
+		{
+			name:  "Reconciling",
+			shoot: &gardenerTypes.Shoot{Status: gardenerTypes.ShootStatus{LastOperation: lastOperation(gardenerTypes.LastOperationTypeReconcile, gardenerTypes.LastOperationStateProcessing)}},
+			phase: types.Reconciling,
+		},
+		{
+			name: "Hibernated",
+			shoot: &gardenerTypes.Shoot{Status: gardenerTypes.ShootStatus{
+				IsHibernated:  true,
+				LastOperation: lastOperation(gardenerTypes.LastOperationTypeReconcile, gardenerTypes.LastOperationStateSucceeded),
+			}},
+			phase: types.Hibernated,
+		},
+		{
+			name: "Deleting",
+			shoot: &gardenerTypes.Shoot{
+				ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &now},
+				Status:     gardenerTypes.ShootStatus{LastOperation: lastOperation(gardenerTypes.LastOperationTypeDelete, gardenerTypes.LastOperationStateProcessing)},
+			},
+			phase: types.Deprovisioning,
+		},
+		{
+			name:  "Error",
+			shoot: &gardenerTypes.Shoot{Status: gardenerTypes.ShootStatus{LastOperation: lastOperation(gardenerTypes.LastOperationTypeReconcile, gardenerTypes.LastOperationStateError)}},
+			phase: types.Errored,
+		},
+		{
+			name:  "Failed",
+			shoot: &gardenerTypes.Shoot{Status: gardenerTypes.ShootStatus{LastOperation: lastOperation(gardenerTypes.LastOperationTypeCreate, gardenerTypes.LastOperationStateFailed)}},
+			phase: types.Failed,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			status := shootStatus(tc.shoot)
+			require.Equal(t, tc.phase, status.Phase)
+			if tc.shoot.Status.LastOperation != nil {
+				require.Equal(t, 42, status.Progress)
+				require.Equal(t, "some description", status.Description)
+			}
+		})
+	}
+}
+
+func TestShootStatusConditions(t *testing.T) {
+	t.Parallel()
+
+	transition := metav1.NewTime(time.Date(2023, 9, 1, 12, 0, 0, 0, time.UTC))
+	shoot := &gardenerTypes.Shoot{
+		Status: gardenerTypes.ShootStatus{
+			Conditions: []gardenerTypes.Condition{
+				{Type: gardenerTypes.ShootAPIServerAvailable, Status: gardenerTypes.ConditionTrue, LastTransitionTime: transition},
+				{Type: gardenerTypes.ShootEveryNodeReady, Status: gardenerTypes.ConditionFalse, Reason: "NodeUnhealthy", Message: "node is not ready"},
+				{Type: "ObservabilityComponentsHealthy", Status: gardenerTypes.ConditionTrue},
+			},
+		},
+	}
+
+	status := shootStatus(shoot)
+	require.Equal(t, []types.Condition{
+		{Type: types.APIServerAvailable, Status: types.ConditionTrue, LastTransitionTime: transition.Time},
+		{Type: types.EveryNodeReady, Status: types.ConditionFalse, Reason: "NodeUnhealthy", Message: "node is not ready"},
+	}, status.Conditions)
+}
+
+func TestShootPatch(t *testing.T) {
+	t.Parallel()
+
+	current, err := toShoot(map[string]interface{}{
+		"cluster_name":       "hydro",
+		"namespace":          "garden-project",
+		"kubernetes_version": "1.26.8",
+		"worker_minimum":     2,
+		"worker_maximum":     4,
+		"machine_type":       "n1-standard-4",
+		"machine_image_name": "gardenlinux",
+	})
+	require.NoError(t, err)
+
+	t.Run("No changes", func(t *testing.T) {
+		t.Parallel()
+		patch, err := shootPatch(current, current.DeepCopy())
+		require.NoError(t, err)
+		require.Nil(t, patch)
+	})
+
+	t.Run("Version, workers and hibernation", func(t *testing.T) {
+		t.Parallel()
+		desired, err := toShoot(map[string]interface{}{
+			"cluster_name":       "hydro",
+			"namespace":          "garden-project",
+			"kubernetes_version": "1.27.5",
+			"worker_minimum":     3,
+			"worker_maximum":     6,
+			"machine_type":       "n1-standard-8",
+			"hibernation_start":  "00 20 * * 1,2,3,4,5",
+		})
+		require.NoError(t, err)
+
+		patch, err := shootPatch(current, desired)
+		require.NoError(t, err)
+		require.NotNil(t, patch)
+
+		patched := &gardenerTypes.Shoot{}
+		currentJSON, err := json.Marshal(current)
+		require.NoError(t, err)
+		patchedJSON, err := strategicpatch.StrategicMergePatch(currentJSON, patch, gardenerTypes.Shoot{})
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(patchedJSON, patched))
+
+		require.Equal(t, "1.27.5", patched.Spec.Kubernetes.Version)
+		require.Len(t, patched.Spec.Provider.Workers, 1)
+		w := patched.Spec.Provider.Workers[0]
+		require.Equal(t, int32(3), w.Minimum)
+		require.Equal(t, int32(6), w.Maximum)
+		require.Equal(t, "n1-standard-8", w.Machine.Type)
+		// the image is kept if the desired configuration does not set it
+		require.Equal(t, "gardenlinux", w.Machine.Image.Name)
+		require.Len(t, patched.Spec.Hibernation.Schedules, 1)
+		require.Equal(t, "00 20 * * 1,2,3,4,5", *patched.Spec.Hibernation.Schedules[0].Start)
+	})
+
+	t.Run("Scaling only", func(t *testing.T) {
+		t.Parallel()
+		live := current.DeepCopy()
+		version := "934.8.0"
+		live.Spec.Provider.Workers[0].Machine.Image.Version = &version
+
+		desired := current.DeepCopy()
+		desired.Spec.Provider.Workers[0].Maximum = 5
+
+		patch, err := shootPatch(live, desired)
+		require.NoError(t, err)
+		require.NotNil(t, patch)
+		require.NotContains(t, string(patch), `"image"`, "The running image version should not be touched")
+	})
+
+	t.Run("Hibernation schedules not configured", func(t *testing.T) {
+		t.Parallel()
+		start, end := "00 20 * * 1,2,3,4,5", "00 08 * * 1,2,3,4,5"
+		live := current.DeepCopy()
+		live.Spec.Hibernation = &gardenerTypes.Hibernation{Schedules: []gardenerTypes.HibernationSchedule{
+			{Start: &start, End: &end},
+			{Start: &start},
+		}}
+
+		patch, err := shootPatch(live, current.DeepCopy())
+		require.NoError(t, err)
+		require.Nil(t, patch, "The schedules of the shoot should be kept")
+	})
+
+	t.Run("Removed worker pool", func(t *testing.T) {
+		t.Parallel()
+		pools := func(pools ...types.WorkerPool) *gardenerTypes.Shoot {
+			sh, err := toShoot(map[string]interface{}{
+				"cluster_name":       "hydro",
+				"namespace":          "garden-project",
+				"kubernetes_version": "1.26.8",
+				"machine_type":       "n1-standard-4",
+				"worker_pools":       pools,
+			})
+			require.NoError(t, err)
+			return sh
+		}
+		system := types.WorkerPool{Name: "system", Minimum: 1, Maximum: 2}
+		batch := types.WorkerPool{Name: "batch", Minimum: 0, Maximum: 10}
+		live := pools(system, batch)
+
+		patch, err := shootPatch(live, pools(system))
+		require.NoError(t, err)
+		require.NotNil(t, patch)
+
+		liveJSON, err := json.Marshal(live)
+		require.NoError(t, err)
+		patchedJSON, err := strategicpatch.StrategicMergePatch(liveJSON, patch, gardenerTypes.Shoot{})
+		require.NoError(t, err)
+		patched := &gardenerTypes.Shoot{}
+		require.NoError(t, json.Unmarshal(patchedJSON, patched))
+		require.Len(t, patched.Spec.Provider.Workers, 1, "The worker that is no longer desired should be removed")
+		require.Equal(t, "system", patched.Spec.Provider.Workers[0].Name)
+	})
+}
+
+func TestWaitForShootOperation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		shoot     *gardenerTypes.Shoot
+		assertErr func(*testing.T, error)
+	}{
+		{
+			name: "Reconciled",
+			shoot: &gardenerTypes.Shoot{Status: gardenerTypes.ShootStatus{
+				ObservedGeneration: 2,
+				LastOperation:      &gardenerTypes.LastOperation{Progress: 100, State: gardenerTypes.LastOperationStateSucceeded},
+			}},
+			assertErr: func(t *testing.T, err error) { require.NoError(t, err) },
+		},
+		{
+			name: "Generation not observed yet",
+			shoot: &gardenerTypes.Shoot{Status: gardenerTypes.ShootStatus{
+				ObservedGeneration: 1,
+				LastOperation:      &gardenerTypes.LastOperation{Progress: 100, State: gardenerTypes.LastOperationStateSucceeded},
+			}},
+			assertErr: func(t *testing.T, err error) {
+				var timeoutErr *types.TimeoutError
+				require.ErrorAs(t, err, &timeoutErr)
+				require.Equal(t, types.UpdateOperation, timeoutErr.Operation)
+			},
+		},
+		{
+			name: "Failed",
+			shoot: &gardenerTypes.Shoot{Status: gardenerTypes.ShootStatus{
+				ObservedGeneration: 2,
+				LastOperation:      &gardenerTypes.LastOperation{Progress: 30, State: gardenerTypes.LastOperationStateFailed, Description: "quota exceeded"},
+			}},
+			assertErr: func(t *testing.T, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "quota exceeded")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			f := &k8sTesting.Fake{}
+			f.AddReactor("get", "shoots", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+				return true, tc.shoot, nil
+			})
+
+			_, err := waitForShootOperation(context.Background(), &gardenerFake.FakeCoreV1beta1{Fake: f}, types.UpdateOperation,
+				"someCluster", "someNamespace", 2, time.Millisecond, 20*time.Millisecond, nil, nil)
+			tc.assertErr(t, err)
+		})
+	}
+}
+
+func TestWaitForHibernation(t *testing.T) {
+	t.Parallel()
+
+	succeeded := &gardenerTypes.LastOperation{Progress: 100, State: gardenerTypes.LastOperationStateSucceeded}
+	tests := []struct {
+		name         string
+		isHibernated bool
+		enabled      bool
+		expectErr    bool
+	}{
+		{name: "Hibernated", isHibernated: true, enabled: true},
+		{name: "Still awake", isHibernated: false, enabled: true, expectErr: true},
+		{name: "Woken up", isHibernated: false, enabled: false},
+		{name: "Still hibernated", isHibernated: true, enabled: false, expectErr: true},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			shoot := &gardenerTypes.Shoot{Status: gardenerTypes.ShootStatus{
+				ObservedGeneration: 1,
+				IsHibernated:       tc.isHibernated,
+				LastOperation:      succeeded,
+			}}
+			f := &k8sTesting.Fake{}
+			f.AddReactor("get", "shoots", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+				return true, shoot, nil
+			})
+
+			_, err := waitForShootOperation(context.Background(), &gardenerFake.FakeCoreV1beta1{Fake: f}, types.UpdateOperation,
+				"someCluster", "someNamespace", 1, time.Millisecond, 20*time.Millisecond, hibernated(tc.enabled), nil)
+			if tc.expectErr {
+				var timeoutErr *types.TimeoutError
+				require.ErrorAs(t, err, &timeoutErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWorkerPools(t *testing.T) {
+	t.Parallel()
+
+	surge := 2
+	cfg := map[string]interface{}{
+		"cluster_name":           "hydro",
+		"machine_type":           "n1-standard-4",
+		"machine_image_name":     "gardenlinux",
+		"machine_image_version":  "934.8.0",
+		"disk_size":              50,
+		"disk_type":              "pd-standard",
+		"zones":                  []string{"europe-west3-a"},
+		"worker_max_surge":       1,
+		"worker_max_unavailable": 0,
+		"worker_minimum":         1,
+		"worker_maximum":         3,
+	}
+
+	t.Run("Single pool", func(t *testing.T) {
+		t.Parallel()
+		shoot, err := toShoot(cfg)
+		require.NoError(t, err)
+		require.Len(t, shoot.Spec.Provider.Workers, 1)
+		require.Equal(t, "cpu-worker", shoot.Spec.Provider.Workers[0].Name)
+	})
+
+	t.Run("Multiple pools", func(t *testing.T) {
+		t.Parallel()
+		poolCfg := map[string]interface{}{}
+		for k, v := range cfg {
+			poolCfg[k] = v
+		}
+		poolCfg["worker_pools"] = []types.WorkerPool{
+			{
+				Name:    "system",
+				Minimum: 2,
+				Maximum: 2,
+				Labels:  map[string]string{"pool": "system"},
+				Taints:  []types.Taint{{Key: "CriticalAddonsOnly", Value: "true", Effect: types.TaintEffectNoSchedule}},
+			},
+			{
+				Name:                "batch",
+				MachineType:         "n1-highmem-8",
+				MachineImageName:    "ubuntu",
+				MachineImageVersion: "22.4.0",
+				Zones:               []string{"europe-west3-b", "europe-west3-c"},
+				Minimum:             0,
+				Maximum:             10,
+				MaxSurge:            &surge,
+				Volume:              &types.Volume{SizeGB: 200, Type: "pd-ssd"},
+			},
+			{
+				Name:             "edge",
+				MachineImageName: "suse-chost",
+				Maximum:          1,
+			},
+		}
+
+		shoot, err := toShoot(poolCfg)
+		require.NoError(t, err)
+		require.Len(t, shoot.Spec.Provider.Workers, 3)
+
+		// the system pool uses the single-pool settings as defaults
+		system := shoot.Spec.Provider.Workers[0]
+		require.Equal(t, "system", system.Name)
+		require.Equal(t, int32(2), system.Minimum)
+		require.Equal(t, int32(2), system.Maximum)
+		require.Equal(t, "n1-standard-4", system.Machine.Type)
+		require.Equal(t, "gardenlinux", system.Machine.Image.Name)
+		require.Equal(t, "934.8.0", *system.Machine.Image.Version)
+		require.Equal(t, []string{"europe-west3-a"}, system.Zones)
+		require.Equal(t, "50Gi", system.Volume.VolumeSize)
+		require.Equal(t, map[string]string{"pool": "system"}, system.Labels)
+		require.Equal(t, []corev1.Taint{{Key: "CriticalAddonsOnly", Value: "true", Effect: corev1.TaintEffectNoSchedule}}, system.Taints)
+
+		batch := shoot.Spec.Provider.Workers[1]
+		require.Equal(t, "batch", batch.Name)
+		require.Equal(t, int32(0), batch.Minimum)
+		require.Equal(t, int32(10), batch.Maximum)
+		require.Equal(t, "n1-highmem-8", batch.Machine.Type)
+		require.Equal(t, "ubuntu", batch.Machine.Image.Name)
+		require.Equal(t, "22.4.0", *batch.Machine.Image.Version)
+		require.Equal(t, []string{"europe-west3-b", "europe-west3-c"}, batch.Zones)
+		require.Equal(t, 2, batch.MaxSurge.IntValue())
+		require.Equal(t, "200Gi", batch.Volume.VolumeSize)
+		require.Equal(t, "pd-ssd", *batch.Volume.Type)
+		require.Empty(t, batch.Taints)
+
+		edge := shoot.Spec.Provider.Workers[2]
+		require.Equal(t, "suse-chost", edge.Machine.Image.Name)
+		require.Equal(t, "934.8.0", *edge.Machine.Image.Version, "A pool that only sets the image name should keep the inherited version")
+	})
+}
+
+func TestShootSpecPurpose(t *testing.T) {
+	t.Parallel()
+
+	spec := shootSpec(map[string]interface{}{"purpose": "evaluation"})
+	require.NotNil(t, spec.Purpose)
+	require.Equal(t, gardenerTypes.ShootPurposeEvaluation, *spec.Purpose)
+
+	spec = shootSpec(map[string]interface{}{})
+	require.Nil(t, spec.Purpose)
+}
+
+func TestDeleteShoot(t *testing.T) {
+	t.Parallel()
+
+	deleting := func(state gardenerTypes.LastOperationState, lastErrors ...string) *gardenerTypes.Shoot {
+		sh := &gardenerTypes.Shoot{ObjectMeta: metav1.ObjectMeta{Name: "hydro", Namespace: "garden-project"}}
+		sh.Status.LastOperation = &gardenerTypes.LastOperation{Type: gardenerTypes.LastOperationTypeDelete, State: state, Description: "Deleting"}
+		for _, e := range lastErrors {
+			sh.Status.LastErrors = append(sh.Status.LastErrors, gardenerTypes.LastError{Description: e})
+		}
+		return sh
+	}
+	notFound := k8sErrors.NewNotFound(gardenerTypes.Resource("shoots"), "hydro")
+
+	// fakeShoots returns the given shoots one after the other for each get and repeats the last one. A nil shoot is missing.
+	// Without shoots, the shoot is missing from the start.
+	fakeShoots := func(patched *[]byte, deleted *bool, shoots ...*gardenerTypes.Shoot) *gardenerFake.FakeCoreV1beta1 {
+		var mu sync.Mutex
+		f := &k8sTesting.Fake{}
+		f.AddReactor("patch", "shoots", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+			if shoots == nil {
+				return true, nil, notFound
+			}
+			*patched = action.(k8sTesting.PatchAction).GetPatch()
+			return true, deleting(gardenerTypes.LastOperationStateSucceeded), nil
+		})
+		f.AddReactor("delete", "shoots", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+			*deleted = true
+			return true, nil, nil
+		})
+		f.AddReactor("get", "shoots", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if len(shoots) == 0 {
+				return true, nil, notFound
+			}
+			sh := shoots[0]
+			if len(shoots) > 1 {
+				shoots = shoots[1:]
+			}
+			if sh == nil {
+				return true, nil, notFound
+			}
+			return true, sh, nil
+		})
+		return &gardenerFake.FakeCoreV1beta1{Fake: f}
+	}
+
+	t.Run("Deleted", func(t *testing.T) {
+		t.Parallel()
+		var patch []byte
+		var deleted bool
+		getter := fakeShoots(&patch, &deleted,
+			deleting(gardenerTypes.LastOperationStateProcessing), deleting(gardenerTypes.LastOperationStateProcessing), nil)
+
+		err := deleteShoot(context.Background(), getter, "hydro", "garden-project", time.Millisecond, time.Second, nil)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"metadata":{"annotations":{"confirmation.gardener.cloud/deletion":"true"}}}`, string(patch))
+		require.True(t, deleted)
+	})
+
+	t.Run("Already deleted", func(t *testing.T) {
+		t.Parallel()
+		var patch []byte
+		var deleted bool
+		err := deleteShoot(context.Background(), fakeShoots(&patch, &deleted), "hydro", "garden-project", time.Millisecond, time.Second, nil)
+		require.NoError(t, err)
+		require.False(t, deleted, "A missing shoot should not be deleted")
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		t.Parallel()
+		var patch []byte
+		var deleted bool
+		getter := fakeShoots(&patch, &deleted, deleting(gardenerTypes.LastOperationStateFailed, "infrastructure still in use", "quota exceeded"))
+		err := deleteShoot(context.Background(), getter, "hydro", "garden-project", time.Millisecond, time.Second, nil)
+		require.EqualError(t, err, "deletion of shoot hydro failed: Deleting, last errors: infrastructure still in use; quota exceeded")
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		t.Parallel()
+		var patch []byte
+		var deleted bool
+		getter := fakeShoots(&patch, &deleted, deleting(gardenerTypes.LastOperationStateError, "waiting for the load balancers"))
+		err := deleteShoot(context.Background(), getter, "hydro", "garden-project", time.Millisecond, 20*time.Millisecond, nil)
+		var timeoutErr *types.TimeoutError
+		require.ErrorAs(t, err, &timeoutErr, "The shoot should still exist when the timeout expires")
+		require.ErrorContains(t, err, "last errors: waiting for the load balancers")
+	})
+}
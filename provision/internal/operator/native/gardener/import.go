@@ -0,0 +1,258 @@
+package gardener
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	gardenerTypes "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/pkg/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kyma-project/hydroform/provision/internal/operator/native/gardener/aws"
+	"github.com/kyma-project/hydroform/provision/internal/operator/native/gardener/azure"
+	"github.com/kyma-project/hydroform/provision/internal/operator/native/gardener/gcp"
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+/*-- Shoot import --*/
+
+// defaultWorkerName is the name of the worker created for a cluster without worker pools.
+const defaultWorkerName = "cpu-worker"
+
+// Import reads the shoot of the configuration from Gardener and returns the cluster and the Gardener configuration that describe it.
+// The cluster info of the returned cluster holds the current status, the endpoint, and the CA of the shoot.
+func Import(ctx context.Context, ops *types.Options, cfg map[string]interface{}) (*types.Cluster, *types.GardenerConfig, error) {
+	client, core, err := seedClients(cfg["credentials_file_path"].(string), ops.Log())
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error creating the gardener client from credentials")
+	}
+	namespace := cfg["namespace"].(string)
+	shoot, err := client.Shoots(namespace).Get(ctx, cfg["cluster_name"].(string), v1.GetOptions{})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get the shoot to import")
+	}
+
+	cluster, gardenerCfg, err := FromShoot(shoot)
+	if err != nil {
+		return nil, nil, err
+	}
+	fillClusterInfo(ctx, ops.Log().With("cluster", shoot.Name), core, namespace, shoot, cluster.ClusterInfo)
+	return cluster, gardenerCfg, nil
+}
+
+// DecodeShoot reads a shoot manifest in YAML or JSON.
+func DecodeShoot(manifest []byte) (*gardenerTypes.Shoot, error) {
+	shoot := &gardenerTypes.Shoot{}
+	if err := yaml.Unmarshal(manifest, shoot); err != nil {
+		return nil, errors.Wrap(err, "could not decode the shoot manifest")
+	}
+	if shoot.Kind != "" && shoot.Kind != "Shoot" {
+		return nil, errors.Errorf("the manifest describes a %s, not a Shoot", shoot.Kind)
+	}
+	if shoot.Name == "" {
+		return nil, errors.New("the shoot manifest has no name")
+	}
+	return shoot, nil
+}
+
+// FromShoot is the reverse of Render. It returns the cluster and the Gardener configuration that render the given shoot.
+// A shoot with a single worker named cpu-worker is described by the cluster-wide settings, any other workers by worker pools.
+// The annotations and the maintenance settings are not imported, as Gardener manages them.
+// The configuration holds a single hibernation schedule; several schedules are not imported, so that updates keep them.
+func FromShoot(shoot *gardenerTypes.Shoot) (*types.Cluster, *types.GardenerConfig, error) {
+	spec := shoot.Spec
+	cluster := &types.Cluster{
+		Name:              shoot.Name,
+		KubernetesVersion: spec.Kubernetes.Version,
+		Location:          spec.Region,
+		ClusterInfo: &types.ClusterInfo{
+			Status: shootStatus(shoot),
+		},
+	}
+	cfg := &types.GardenerConfig{
+		TargetProvider:       types.ProviderType(spec.Provider.Type),
+		TargetSecret:         stringValue(spec.SecretBindingName),
+		SeedName:             stringValue(spec.SeedName),
+		PrivilegedContainers: spec.Kubernetes.AllowPrivilegedContainers,
+	}
+	if spec.Purpose != nil {
+		cfg.Purpose = string(*spec.Purpose)
+	}
+	if n := spec.Networking; n != nil {
+		cfg.NetworkingType = stringValue(n.Type)
+		cfg.NetworkingNodes = stringValue(n.Nodes)
+	}
+	if h := spec.Hibernation; h != nil && len(h.Schedules) == 1 {
+		cfg.HibernationStart = stringValue(h.Schedules[0].Start)
+		cfg.HibernationEnd = stringValue(h.Schedules[0].End)
+		cfg.HibernationLocation = stringValue(h.Schedules[0].Location)
+	}
+	if api := spec.Kubernetes.KubeAPIServer; api != nil && api.OIDCConfig != nil {
+		importOIDC(api.OIDCConfig, cfg)
+	}
+
+	workers := spec.Provider.Workers
+	if len(workers) == 0 {
+		return nil, nil, errors.Errorf("shoot %s has no workers", shoot.Name)
+	}
+	// the first worker holds the cluster-wide settings
+	importWorker(workers[0], cluster, cfg)
+	if len(workers) > 1 || workers[0].Name != defaultWorkerName {
+		for _, w := range workers {
+			cluster.WorkerPools = append(cluster.WorkerPools, workerPool(w))
+		}
+	}
+	for _, w := range workers {
+		cluster.NodeCount += int(w.Minimum)
+	}
+	if cluster.NodeCount < 1 {
+		cluster.NodeCount = 1
+	}
+
+	if err := importProvider(spec.Provider, cfg); err != nil {
+		return nil, nil, errors.Wrapf(err, "could not read the provider configuration of shoot %s", shoot.Name)
+	}
+	return cluster, cfg, nil
+}
+
+// importWorker sets the cluster-wide and the single-pool settings from the worker.
+func importWorker(w gardenerTypes.Worker, cluster *types.Cluster, cfg *types.GardenerConfig) {
+	cluster.MachineType = w.Machine.Type
+	if w.Machine.Image != nil {
+		cfg.MachineImageName = w.Machine.Image.Name
+		cfg.MachineImageVersion = stringValue(w.Machine.Image.Version)
+	}
+	if w.Volume != nil {
+		cluster.DiskSizeGB = volumeSizeGB(w.Volume.VolumeSize)
+		cfg.DiskType = stringValue(w.Volume.Type)
+	}
+
+	minimum, maximum := int(w.Minimum), int(w.Maximum)
+	cfg.WorkerMinimum, cfg.WorkerMaximum = &minimum, &maximum
+	cfg.WorkerMaxSurge = intOrStringValue(w.MaxSurge)
+	cfg.WorkerMaxUnavailable = intOrStringValue(w.MaxUnavailable)
+	cfg.Zones = w.Zones
+}
+
+// workerPool describes the worker as a pool.
+func workerPool(w gardenerTypes.Worker) types.WorkerPool {
+	pool := types.WorkerPool{
+		Name:           w.Name,
+		MachineType:    w.Machine.Type,
+		Zones:          w.Zones,
+		Minimum:        int(w.Minimum),
+		Maximum:        int(w.Maximum),
+		MaxSurge:       intOrStringValue(w.MaxSurge),
+		MaxUnavailable: intOrStringValue(w.MaxUnavailable),
+		Labels:         w.Labels,
+	}
+	if w.Machine.Image != nil {
+		pool.MachineImageName = w.Machine.Image.Name
+		pool.MachineImageVersion = stringValue(w.Machine.Image.Version)
+	}
+	for _, t := range w.Taints {
+		pool.Taints = append(pool.Taints, types.Taint{
+			Key:    t.Key,
+			Value:  t.Value,
+			Effect: types.TaintEffect(t.Effect),
+		})
+	}
+	if w.Volume != nil {
+		pool.Volume = &types.Volume{
+			SizeGB: volumeSizeGB(w.Volume.VolumeSize),
+			Type:   stringValue(w.Volume.Type),
+		}
+	}
+	return pool
+}
+
+func importOIDC(o *gardenerTypes.OIDCConfig, cfg *types.GardenerConfig) {
+	cfg.OIDCCABundle = stringValue(o.CABundle)
+	cfg.OIDCClientID = stringValue(o.ClientID)
+	cfg.OIDCGroupsClaim = stringValue(o.GroupsClaim)
+	cfg.OIDCGroupsPrefix = stringValue(o.GroupsPrefix)
+	cfg.OIDCIssuerURL = stringValue(o.IssuerURL)
+	cfg.OIDCRequiredClaims = o.RequiredClaims
+	cfg.OIDCSigningAlgs = o.SigningAlgs
+	cfg.OIDCUsernameClaim = stringValue(o.UsernameClaim)
+	cfg.OIDCUsernamePrefix = stringValue(o.UsernamePrefix)
+}
+
+// importProvider reads the settings of the target provider from the infrastructure and control plane configurations.
+func importProvider(p gardenerTypes.Provider, cfg *types.GardenerConfig) error {
+	switch types.ProviderType(p.Type) {
+	case types.GCP:
+		infra := gcp.InfrastructureConfig{}
+		if err := decodeRaw(p.InfrastructureConfig, &infra); err != nil {
+			return err
+		}
+		cp := gcp.ControlPlane{}
+		if err := decodeRaw(p.ControlPlaneConfig, &cp); err != nil {
+			return err
+		}
+		cfg.GCP = &types.GardenerGCPConfig{
+			ControlPlaneZone: cp.Zone,
+			WorkerCIDR:       infra.Networks.Worker,
+		}
+		if infra.Networks.Workers != nil {
+			cfg.GCP.WorkerCIDR = *infra.Networks.Workers
+		}
+	case types.AWS:
+		infra := aws.InfrastructureConfig{}
+		if err := decodeRaw(p.InfrastructureConfig, &infra); err != nil {
+			return err
+		}
+		cfg.AWS = &types.GardenerAWSConfig{
+			VnetCIDR: stringValue(infra.Networks.VPC.CIDR),
+		}
+	case types.Azure:
+		infra := azure.InfrastructureConfig{}
+		if err := decodeRaw(p.InfrastructureConfig, &infra); err != nil {
+			return err
+		}
+		cfg.Azure = &types.GardenerAzureConfig{
+			VnetCIDR:         stringValue(infra.Networks.VNet.CIDR),
+			WorkerCIDR:       infra.Networks.Workers,
+			ServiceEndpoints: infra.Networks.ServiceEndpoints,
+		}
+	}
+	return nil
+}
+
+// decodeRaw decodes a provider configuration of a shoot. A missing configuration leaves out unchanged.
+func decodeRaw(raw *runtime.RawExtension, out interface{}) error {
+	if raw == nil || len(raw.Raw) == 0 {
+		return nil
+	}
+	return json.Unmarshal(raw.Raw, out)
+}
+
+// volumeSizeGB returns the size in GB of a volume size such as 50Gi, or 0 if it has another format.
+func volumeSizeGB(size string) int {
+	gb, err := strconv.Atoi(strings.TrimSuffix(size, "Gi"))
+	if err != nil {
+		return 0
+	}
+	return gb
+}
+
+// intOrStringValue returns the value of an integer setting. Percentages cannot be imported and are returned as nil.
+func intOrStringValue(v *intstr.IntOrString) *int {
+	if v == nil || v.Type != intstr.Int {
+		return nil
+	}
+	i := v.IntValue()
+	return &i
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,89 @@
+package gardener
+
+import (
+	"testing"
+
+	gardenerTypes "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+func TestFromShootWorkerPools(t *testing.T) {
+	t.Parallel()
+
+	surge := 2
+	shoot, err := Render(map[string]interface{}{
+		"cluster_name":           "hydro",
+		"namespace":              "garden-project",
+		"kubernetes_version":     "1.26.8",
+		"machine_type":           "n1-standard-4",
+		"machine_image_name":     "gardenlinux",
+		"disk_size":              50,
+		"disk_type":              "pd-standard",
+		"zones":                  []string{"europe-west3-a"},
+		"worker_max_surge":       1,
+		"worker_max_unavailable": 0,
+		"worker_minimum":         1,
+		"worker_maximum":         3,
+		"worker_pools": []types.WorkerPool{
+			{
+				Name:    "system",
+				Minimum: 2,
+				Maximum: 2,
+				Taints:  []types.Taint{{Key: "CriticalAddonsOnly", Value: "true", Effect: types.TaintEffectNoSchedule}},
+			},
+			{
+				Name:        "batch",
+				MachineType: "n1-highmem-8",
+				Maximum:     10,
+				MaxSurge:    &surge,
+				Volume:      &types.Volume{SizeGB: 200, Type: "pd-ssd"},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	cluster, cfg, err := FromShoot(shoot)
+	require.NoError(t, err)
+	require.Equal(t, "hydro", cluster.Name)
+	require.Equal(t, "n1-standard-4", cluster.MachineType, "The first worker should hold the cluster-wide settings")
+	require.Equal(t, 50, cluster.DiskSizeGB)
+	require.Equal(t, 2, cluster.NodeCount)
+	require.Len(t, cluster.WorkerPools, 2)
+
+	// rendering the imported pools again results in the same workers
+	rendered := cfg.Map()
+	rendered["cluster_name"] = cluster.Name
+	rendered["machine_type"] = cluster.MachineType
+	rendered["disk_size"] = cluster.DiskSizeGB
+	rendered["worker_pools"] = cluster.WorkerPools
+	again, err := Render(rendered)
+	require.NoError(t, err)
+	require.Equal(t, shoot.Spec.Provider.Workers, again.Spec.Provider.Workers)
+}
+
+func TestFromShootUnsupportedValues(t *testing.T) {
+	t.Parallel()
+
+	percent := intstr.FromString("25%")
+	shoot := &gardenerTypes.Shoot{}
+	shoot.Name = "hydro"
+	shoot.Spec.Provider.Workers = []gardenerTypes.Worker{{Name: defaultWorkerName, MaxSurge: &percent}}
+
+	cluster, cfg, err := FromShoot(shoot)
+	require.NoError(t, err)
+	require.Nil(t, cfg.WorkerMaxSurge, "A percentage cannot be imported")
+	require.Equal(t, 1, cluster.NodeCount)
+
+	start := "00 20 * * 1,2,3,4,5"
+	shoot.Spec.Hibernation = &gardenerTypes.Hibernation{Schedules: []gardenerTypes.HibernationSchedule{{Start: &start}, {Start: &start}}}
+	_, cfg, err = FromShoot(shoot)
+	require.NoError(t, err)
+	require.Empty(t, cfg.HibernationStart, "Several hibernation schedules cannot be imported")
+
+	shoot.Spec.Provider.Workers = nil
+	_, _, err = FromShoot(shoot)
+	require.EqualError(t, err, "shoot hydro has no workers")
+}
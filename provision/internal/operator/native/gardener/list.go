@@ -0,0 +1,69 @@
+package gardener
+
+import (
+	"context"
+
+	gardenerTypes "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardenerApi "github.com/gardener/gardener/pkg/client/core/clientset/versioned/typed/core/v1beta1"
+	"github.com/pkg/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+/*-- Shoot inventory --*/
+
+// List returns the shoots in the namespace of the configuration that match the filter.
+// The cluster info of the listed clusters holds everything but the CA, which would need an API call per shoot.
+func List(ctx context.Context, ops *types.Options, cfg map[string]interface{}, filter types.ListFilter) ([]types.Cluster, error) {
+	client, _, err := seedClients(cfg["credentials_file_path"].(string), ops.Log())
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the gardener client from credentials")
+	}
+	return listShoots(ctx, client, cfg["namespace"].(string), filter)
+}
+
+// listShoots selects the shoots by their labels on the server and by their annotations on the client.
+func listShoots(ctx context.Context, getter gardenerApi.ShootsGetter, namespace string, filter types.ListFilter) ([]types.Cluster, error) {
+	list, err := getter.Shoots(namespace).List(ctx, v1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(filter.Labels).String(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list the shoots")
+	}
+
+	clusters := []types.Cluster{}
+	for i := range list.Items {
+		shoot := &list.Items[i]
+		if !hasAnnotations(shoot, filter.Annotations) {
+			continue
+		}
+
+		cluster, _, err := FromShoot(shoot)
+		if err != nil {
+			// shoots that cannot be imported are still listed with their basic settings
+			cluster = &types.Cluster{
+				Name:              shoot.Name,
+				KubernetesVersion: shoot.Spec.Kubernetes.Version,
+				Location:          shoot.Spec.Region,
+				ClusterInfo: &types.ClusterInfo{
+					Status: shootStatus(shoot),
+				},
+			}
+		}
+		shootInfo(shoot, cluster.ClusterInfo)
+		clusters = append(clusters, *cluster)
+	}
+	return clusters, nil
+}
+
+// hasAnnotations checks if the shoot has all of the given annotations.
+func hasAnnotations(shoot *gardenerTypes.Shoot, annotations map[string]string) bool {
+	for k, v := range annotations {
+		if value, ok := shoot.Annotations[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package gardener
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gardenerTypes "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardenerFake "github.com/gardener/gardener/pkg/client/core/clientset/versioned/typed/core/v1beta1/fake"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sTesting "k8s.io/client-go/testing"
+
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+func TestListShoots(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	shoot := func(name string, labels, annotations map[string]string) gardenerTypes.Shoot {
+		sh, err := Render(map[string]interface{}{
+			"cluster_name":       name,
+			"namespace":          "garden-project",
+			"kubernetes_version": "1.26.8",
+			"location":           "europe-west3",
+			"machine_type":       "n1-standard-4",
+		})
+		require.NoError(t, err)
+		sh.Labels = labels
+		sh.Annotations = annotations
+		sh.CreationTimestamp = metav1.NewTime(created)
+		sh.Status.LastOperation = &gardenerTypes.LastOperation{
+			Type:     gardenerTypes.LastOperationTypeReconcile,
+			State:    gardenerTypes.LastOperationStateSucceeded,
+			Progress: 100,
+		}
+		return *sh
+	}
+
+	f := &k8sTesting.Fake{}
+	f.AddReactor("list", "shoots", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		return true, &gardenerTypes.ShootList{Items: []gardenerTypes.Shoot{
+			shoot("hydro", map[string]string{"team": "kyma"}, map[string]string{"gardener.cloud/created-by": "ci@example.com", "tool": "hydroform"}),
+			shoot("manual", map[string]string{"team": "kyma"}, map[string]string{"gardener.cloud/created-by": "admin@example.com"}),
+			shoot("other", map[string]string{"team": "other"}, nil),
+		}}, nil
+	})
+	getter := &gardenerFake.FakeCoreV1beta1{Fake: f}
+
+	clusters, err := listShoots(context.Background(), getter, "garden-project", types.ListFilter{})
+	require.NoError(t, err)
+	require.Len(t, clusters, 3)
+
+	hydro := clusters[0]
+	require.Equal(t, "hydro", hydro.Name)
+	require.Equal(t, "1.26.8", hydro.KubernetesVersion)
+	require.Equal(t, "europe-west3", hydro.Location)
+	require.Equal(t, types.Provisioned, hydro.ClusterInfo.Status.Phase)
+	require.Equal(t, created, *hydro.ClusterInfo.CreatedAt)
+	require.Equal(t, "ci@example.com", hydro.ClusterInfo.CreatedBy)
+	require.Equal(t, map[string]string{"team": "kyma"}, hydro.ClusterInfo.Labels)
+
+	clusters, err = listShoots(context.Background(), getter, "garden-project", types.ListFilter{Labels: map[string]string{"team": "kyma"}})
+	require.NoError(t, err)
+	require.Len(t, clusters, 2, "The shoots should be filtered by their labels")
+
+	clusters, err = listShoots(context.Background(), getter, "garden-project", types.ListFilter{
+		Labels:      map[string]string{"team": "kyma"},
+		Annotations: map[string]string{"tool": "hydroform"},
+	})
+	require.NoError(t, err)
+	require.Len(t, clusters, 1, "The shoots should be filtered by their annotations")
+	require.Equal(t, "hydro", clusters[0].Name)
+}
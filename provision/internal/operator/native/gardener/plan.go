@@ -0,0 +1,197 @@
+package gardener
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	gardenerTypes "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardenerApi "github.com/gardener/gardener/pkg/client/core/clientset/versioned/typed/core/v1beta1"
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+/*-- Shoot plan --*/
+
+// Render returns the shoot that Create sends to Gardener for the configuration. It does not contact Gardener.
+func Render(cfg map[string]interface{}) (*gardenerTypes.Shoot, error) {
+	shoot, err := toShoot(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "error generating shoot spec from config")
+	}
+	shoot.TypeMeta = v1.TypeMeta{
+		APIVersion: gardenerTypes.SchemeGroupVersion.String(),
+		Kind:       "Shoot",
+	}
+	return shoot, nil
+}
+
+// Diff compares the shoot rendered for the configuration with the live shoot.
+func Diff(ctx context.Context, ops *types.Options, cfg map[string]interface{}) (*types.PlanDiff, error) {
+	desired, err := Render(cfg)
+	if err != nil {
+		return nil, err
+	}
+	client, _, err := seedClients(cfg["credentials_file_path"].(string), ops.Log())
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the gardener client from credentials")
+	}
+	return diffShoot(ctx, client, desired)
+}
+
+// diffShoot compares the desired shoot with the live one. For a missing shoot the whole desired shoot is the difference.
+// For an existing shoot the whole desired spec is compared with the live spec, leaving out the live fields the desired spec does not set,
+// such as the defaults and the fields set by Gardener. The patch holds only the changes Update would apply.
+func diffShoot(ctx context.Context, getter gardenerApi.ShootsGetter, desired *gardenerTypes.Shoot) (*types.PlanDiff, error) {
+	current, err := getter.Shoots(desired.Namespace).Get(ctx, desired.Name, v1.GetOptions{})
+	if k8sErrors.IsNotFound(err) {
+		diff, err := yamlDiff(nil, desired)
+		return &types.PlanDiff{Diff: diff}, err
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get the shoot to compare")
+	}
+
+	patch, err := shootPatch(current, desired)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute the shoot patch")
+	}
+
+	live, err := toValue(current.Spec)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read the live shoot spec")
+	}
+	planned, err := toValue(desired.Spec)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read the planned shoot spec")
+	}
+	planned = dropEmpty(planned)
+	live = pruneTo(live, planned)
+	if reflect.DeepEqual(live, planned) {
+		return &types.PlanDiff{Exists: true, Patch: patch}, nil
+	}
+	diff, err := yamlDiff(live, planned)
+	return &types.PlanDiff{Exists: true, Patch: patch, Diff: diff}, err
+}
+
+// toValue converts v to the maps, lists and scalars of its JSON representation.
+func toValue(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var res interface{}
+	return res, json.Unmarshal(data, &res)
+}
+
+// dropEmpty leaves out the empty strings and objects, which the shoot types render for fields that are not set.
+// Gardener defaults some of them, such as the machine image name.
+func dropEmpty(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		res := map[string]interface{}{}
+		for k, item := range t {
+			item = dropEmpty(item)
+			if m, ok := item.(map[string]interface{}); (ok && len(m) == 0) || item == "" {
+				continue
+			}
+			res[k] = item
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(t))
+		for i, item := range t {
+			res[i] = dropEmpty(item)
+		}
+		return res
+	default:
+		return v
+	}
+}
+
+// pruneTo leaves out the fields of the live value that the desired value does not set.
+// The items of lists are matched by their name if they have one, such as the workers, and by their position otherwise.
+// Live items without a desired counterpart are kept as they are, as they are a difference.
+func pruneTo(live, desired interface{}) interface{} {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return live
+		}
+		res := map[string]interface{}{}
+		for k, dv := range d {
+			if lv, ok := l[k]; ok {
+				res[k] = pruneTo(lv, dv)
+			}
+		}
+		return res
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok {
+			return live
+		}
+		res := make([]interface{}, len(l))
+		for i, lv := range l {
+			if dv, ok := counterpart(d, lv, i); ok {
+				res[i] = pruneTo(lv, dv)
+			} else {
+				res[i] = lv
+			}
+		}
+		return res
+	default:
+		return live
+	}
+}
+
+// counterpart returns the desired item with the name of the live item, or the desired item at the same position if the items have no name.
+func counterpart(desired []interface{}, live interface{}, i int) (interface{}, bool) {
+	if name, ok := itemName(live); ok {
+		for _, d := range desired {
+			if n, ok := itemName(d); ok && n == name {
+				return d, true
+			}
+		}
+		return nil, false
+	}
+	if i < len(desired) {
+		return desired[i], true
+	}
+	return nil, false
+}
+
+func itemName(item interface{}) (string, bool) {
+	m, ok := item.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	name, ok := m["name"].(string)
+	return name, ok
+}
+
+// yamlDiff returns a unified diff of the YAML representations of the given values. A nil value is compared as an empty document.
+func yamlDiff(live, desired interface{}) (string, error) {
+	var a, b []byte
+	var err error
+	if live != nil {
+		if a, err = yaml.Marshal(live); err != nil {
+			return "", err
+		}
+	}
+	if b, err = yaml.Marshal(desired); err != nil {
+		return "", err
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(a)),
+		B:        difflib.SplitLines(string(b)),
+		FromFile: "live",
+		ToFile:   "planned",
+		Context:  3,
+	})
+}
@@ -0,0 +1,131 @@
+package gardener
+
+import (
+	"context"
+	"testing"
+
+	gardenerTypes "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardenerFake "github.com/gardener/gardener/pkg/client/core/clientset/versioned/typed/core/v1beta1/fake"
+	"github.com/stretchr/testify/require"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sTesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/yaml"
+)
+
+var planConfig = map[string]interface{}{
+	"cluster_name":       "hydro",
+	"namespace":          "garden-project",
+	"kubernetes_version": "1.26.8",
+	"worker_minimum":     2,
+	"worker_maximum":     4,
+	"machine_type":       "n1-standard-4",
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	shoot, err := Render(planConfig)
+	require.NoError(t, err)
+	require.Equal(t, "core.gardener.cloud/v1beta1", shoot.APIVersion)
+	require.Equal(t, "Shoot", shoot.Kind)
+	require.Equal(t, "hydro", shoot.Name)
+	require.Equal(t, "garden-project", shoot.Namespace)
+
+	out, err := yaml.Marshal(shoot)
+	require.NoError(t, err)
+	rendered := &gardenerTypes.Shoot{}
+	require.NoError(t, yaml.Unmarshal(out, rendered))
+	require.Equal(t, shoot, rendered, "The rendered manifest should contain the whole shoot")
+}
+
+func TestDiffShoot(t *testing.T) {
+	t.Parallel()
+
+	desired, err := Render(planConfig)
+	require.NoError(t, err)
+	podsCIDR, enabled, secretBinding := "100.96.0.0/11", true, "other-secret"
+
+	getter := func(shoot *gardenerTypes.Shoot) *gardenerFake.FakeCoreV1beta1 {
+		f := &k8sTesting.Fake{}
+		f.AddReactor("get", "shoots", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+			if shoot == nil {
+				return true, nil, k8sErrors.NewNotFound(gardenerTypes.Resource("shoots"), "hydro")
+			}
+			return true, shoot, nil
+		})
+		return &gardenerFake.FakeCoreV1beta1{Fake: f}
+	}
+
+	t.Run("Missing shoot", func(t *testing.T) {
+		t.Parallel()
+		diff, err := diffShoot(context.Background(), getter(nil), desired)
+		require.NoError(t, err)
+		require.False(t, diff.Exists)
+		require.False(t, diff.Empty())
+		require.Nil(t, diff.Patch)
+		require.Contains(t, diff.Diff, "+kind: Shoot")
+	})
+
+	t.Run("Unchanged shoot", func(t *testing.T) {
+		t.Parallel()
+		diff, err := diffShoot(context.Background(), getter(desired.DeepCopy()), desired)
+		require.NoError(t, err)
+		require.True(t, diff.Empty())
+		require.Empty(t, diff.Diff)
+	})
+
+	t.Run("Shoot with defaults", func(t *testing.T) {
+		t.Parallel()
+		live := desired.DeepCopy()
+		live.Spec.Provider.Workers[0].Machine.Image = &gardenerTypes.ShootMachineImage{Name: "gardenlinux"}
+		live.Spec.Networking = &gardenerTypes.Networking{Pods: &podsCIDR}
+		live.Spec.Kubernetes.EnableStaticTokenKubeconfig = &enabled
+		live.Status.TechnicalID = "shoot--project--hydro"
+
+		diff, err := diffShoot(context.Background(), getter(live), desired)
+		require.NoError(t, err)
+		require.True(t, diff.Empty(), "The defaults and the status of the live shoot should not be compared: %s", diff.Diff)
+	})
+
+	t.Run("Shoot that Update cannot change", func(t *testing.T) {
+		t.Parallel()
+		cfg := map[string]interface{}{}
+		for k, v := range planConfig {
+			cfg[k] = v
+		}
+		cfg["location"] = "europe-west3"
+		cfg["target_secret"] = "gcp-secret"
+		planned, err := Render(cfg)
+		require.NoError(t, err)
+		live := planned.DeepCopy()
+		live.Spec.Region = "europe-west1"
+		live.Spec.SecretBindingName = &secretBinding
+
+		diff, err := diffShoot(context.Background(), getter(live), planned)
+		require.NoError(t, err)
+		require.False(t, diff.Empty())
+		require.Nil(t, diff.Patch, "Update does not change the region or the secret binding")
+		require.Contains(t, diff.Diff, "-region: europe-west1")
+		require.Contains(t, diff.Diff, "+region: europe-west3")
+		require.Contains(t, diff.Diff, "-secretBindingName: other-secret")
+		require.Contains(t, diff.Diff, "+secretBindingName: gcp-secret")
+	})
+
+	t.Run("Changed shoot", func(t *testing.T) {
+		t.Parallel()
+		live := desired.DeepCopy()
+		live.Spec.Kubernetes.Version = "1.25.9"
+		live.Spec.Provider.Workers[0].Maximum = 3
+
+		diff, err := diffShoot(context.Background(), getter(live), desired)
+		require.NoError(t, err)
+		require.True(t, diff.Exists)
+		require.False(t, diff.Empty())
+		require.NotNil(t, diff.Patch)
+		require.Contains(t, diff.Diff, "-  version: 1.25.9")
+		require.Contains(t, diff.Diff, "+  version: 1.26.8")
+		require.Contains(t, diff.Diff, "-    maximum: 3")
+		require.Contains(t, diff.Diff, "+    maximum: 4")
+	})
+}
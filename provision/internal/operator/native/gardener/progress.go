@@ -0,0 +1,101 @@
+package gardener
+
+import (
+	"io"
+	"log/slog"
+	"reflect"
+
+	gardenerTypes "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+/*-- Progress events --*/
+
+// progressReporter emits the progress events of an operation on a shoot to the ProgressFunc of the options.
+// It only emits an event if the reported values changed since the previous one.
+type progressReporter struct {
+	ops       *types.Options
+	operation types.Operation
+	cluster   string
+	last      types.ProgressEvent
+}
+
+func newProgressReporter(ops *types.Options, operation types.Operation, cluster string) *progressReporter {
+	return &progressReporter{ops: ops, operation: operation, cluster: cluster}
+}
+
+// started emits the start of the operation with the shoot returned by Gardener, which can be nil.
+func (r *progressReporter) started(shoot *gardenerTypes.Shoot) {
+	if r == nil {
+		return
+	}
+	r.last = r.event(types.OperationStarted, shoot)
+	r.ops.ReportProgress(r.last)
+}
+
+// observe emits the changes of the shoot since it was observed the last time.
+func (r *progressReporter) observe(shoot *gardenerTypes.Shoot) {
+	if r == nil {
+		return
+	}
+	e := r.event("", shoot)
+	if e.Phase != r.last.Phase || e.State != r.last.State {
+		e.Type = types.StateChanged
+		r.ops.ReportProgress(e)
+	}
+	if e.Progress != r.last.Progress || e.Description != r.last.Description {
+		e.Type = types.OperationProgressed
+		r.ops.ReportProgress(e)
+	}
+	if len(e.LastErrors) > 0 && !reflect.DeepEqual(e.LastErrors, r.last.LastErrors) {
+		e.Type = types.LastErrorsReported
+		r.ops.ReportProgress(e)
+	}
+	r.last = e
+}
+
+// completed emits the end of the operation with the last known shoot, which can be nil, and the error of the operation.
+func (r *progressReporter) completed(shoot *gardenerTypes.Shoot, err error) {
+	if r == nil {
+		return
+	}
+	e := r.last
+	if shoot != nil {
+		e = r.event("", shoot)
+	}
+	e.Type = types.OperationCompleted
+	e.Err = err
+	r.ops.ReportProgress(e)
+}
+
+// log returns the logger of the options. A nil reporter discards the logs.
+func (r *progressReporter) log() *slog.Logger {
+	if r == nil {
+		return slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return r.ops.Log().With("cluster", r.cluster)
+}
+
+// event describes the state of the shoot.
+func (r *progressReporter) event(t types.ProgressEventType, shoot *gardenerTypes.Shoot) types.ProgressEvent {
+	e := types.ProgressEvent{
+		Type:      t,
+		Operation: r.operation,
+		Cluster:   r.cluster,
+	}
+	if shoot == nil {
+		return e
+	}
+
+	e.Phase = shootPhase(shoot)
+	if op := shoot.Status.LastOperation; op != nil {
+		e.State = string(op.State)
+		e.Progress = int(op.Progress)
+		e.Description = op.Description
+	}
+	for _, le := range shoot.Status.LastErrors {
+		e.LastErrors = append(e.LastErrors, le.Description)
+	}
+	return e
+}
@@ -0,0 +1,106 @@
+package gardener
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	gardenerTypes "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardenerFake "github.com/gardener/gardener/pkg/client/core/clientset/versioned/typed/core/v1beta1/fake"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sTesting "k8s.io/client-go/testing"
+
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+func TestProgressEvents(t *testing.T) {
+	t.Parallel()
+
+	lastOperation := func(state gardenerTypes.LastOperationState, progress int32, description string) *gardenerTypes.Shoot {
+		return &gardenerTypes.Shoot{Status: gardenerTypes.ShootStatus{
+			LastOperation: &gardenerTypes.LastOperation{
+				Type:        gardenerTypes.LastOperationTypeCreate,
+				State:       state,
+				Progress:    progress,
+				Description: description,
+			},
+		}}
+	}
+	withErrors := lastOperation(gardenerTypes.LastOperationStateError, 40, "Waiting for the infrastructure")
+	withErrors.Status.LastErrors = []gardenerTypes.LastError{{Description: "quota exceeded"}}
+	shoots := []*gardenerTypes.Shoot{
+		lastOperation(gardenerTypes.LastOperationStateProcessing, 10, "Creating the infrastructure"),
+		lastOperation(gardenerTypes.LastOperationStateProcessing, 10, "Creating the infrastructure"),
+		withErrors,
+		lastOperation(gardenerTypes.LastOperationStateProcessing, 80, "Waiting for the nodes"),
+		lastOperation(gardenerTypes.LastOperationStateSucceeded, 100, "Shoot cluster has been created"),
+	}
+
+	var mu sync.Mutex
+	f := &k8sTesting.Fake{}
+	f.AddReactor("get", "shoots", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		sh := shoots[0]
+		if len(shoots) > 1 {
+			shoots = shoots[1:]
+		}
+		return true, sh, nil
+	})
+
+	var events []types.ProgressEvent
+	ops := &types.Options{Progress: func(e types.ProgressEvent) {
+		events = append(events, e)
+	}}
+	progress := newProgressReporter(ops, types.CreateOperation, "hydro")
+
+	progress.started(&gardenerTypes.Shoot{})
+	shoot, err := waitForShootOperation(context.Background(), &gardenerFake.FakeCoreV1beta1{Fake: f}, types.CreateOperation,
+		"hydro", "garden-project", 0, time.Millisecond, time.Second, nil, progress)
+	progress.completed(shoot, err)
+	require.NoError(t, err)
+
+	var got []types.ProgressEventType
+	for _, e := range events {
+		require.Equal(t, types.CreateOperation, e.Operation)
+		require.Equal(t, "hydro", e.Cluster)
+		require.False(t, e.Time.IsZero())
+		got = append(got, e.Type)
+	}
+	require.Equal(t, []types.ProgressEventType{
+		types.OperationStarted,
+		// processing at 10%
+		types.StateChanged, types.OperationProgressed,
+		// an error that Gardener retries
+		types.StateChanged, types.OperationProgressed, types.LastErrorsReported,
+		// processing at 80%
+		types.StateChanged, types.OperationProgressed,
+		// succeeded
+		types.StateChanged, types.OperationProgressed,
+		types.OperationCompleted,
+	}, got, "Unchanged shoots should not emit events")
+
+	require.Equal(t, []string{"quota exceeded"}, events[5].LastErrors)
+	require.Equal(t, 80, events[7].Progress)
+	completed := events[len(events)-1]
+	require.Equal(t, types.Provisioned, completed.Phase)
+	require.Equal(t, 100, completed.Progress)
+	require.NoError(t, completed.Err)
+}
+
+func TestProgressReporterWithoutCallback(t *testing.T) {
+	t.Parallel()
+
+	// neither a nil reporter nor options without a ProgressFunc should fail
+	var nilReporter *progressReporter
+	nilReporter.started(nil)
+	nilReporter.observe(&gardenerTypes.Shoot{})
+	nilReporter.completed(nil, nil)
+
+	r := newProgressReporter(&types.Options{}, types.DeleteOperation, "hydro")
+	r.started(nil)
+	r.observe(&gardenerTypes.Shoot{})
+	r.completed(nil, nil)
+}
@@ -0,0 +1,71 @@
+package gke
+
+// The types below are a subset of the GKE container API v1 resources.
+// See https://cloud.google.com/kubernetes-engine/docs/reference/rest/v1/projects.locations.clusters
+
+// Cluster is a Google Kubernetes Engine cluster.
+type Cluster struct {
+	// Name is the name of the cluster, unique within the project and location.
+	Name string `json:"name"`
+	// InitialClusterVersion is the Kubernetes version the cluster is created with.
+	InitialClusterVersion string `json:"initialClusterVersion,omitempty"`
+	// CurrentMasterVersion is the Kubernetes version of the control plane.
+	CurrentMasterVersion string `json:"currentMasterVersion,omitempty"`
+	// NodePools are the node pools associated with the cluster.
+	NodePools []NodePool `json:"nodePools,omitempty"`
+	// Endpoint is the IP address of the cluster master endpoint.
+	Endpoint string `json:"endpoint,omitempty"`
+	// MasterAuth contains the authentication information of the cluster master.
+	MasterAuth *MasterAuth `json:"masterAuth,omitempty"`
+	// Status is the current status of the cluster.
+	Status string `json:"status,omitempty"`
+	// StatusMessage contains additional information about the current status of the cluster.
+	StatusMessage string `json:"statusMessage,omitempty"`
+}
+
+// NodePool is a group of nodes with the same configuration.
+type NodePool struct {
+	// Name is the name of the node pool.
+	Name string `json:"name"`
+	// InitialNodeCount is the number of nodes created in the node pool.
+	InitialNodeCount int `json:"initialNodeCount,omitempty"`
+	// Version is the Kubernetes version of the nodes.
+	Version string `json:"version,omitempty"`
+	// Config is the node configuration of the pool.
+	Config *NodeConfig `json:"config,omitempty"`
+}
+
+// NodeConfig contains the parameters used to create the nodes of a node pool.
+type NodeConfig struct {
+	// MachineType is the Compute Engine machine type of the nodes.
+	MachineType string `json:"machineType,omitempty"`
+	// DiskSizeGb is the size of the boot disk of each node in GB.
+	DiskSizeGb int `json:"diskSizeGb,omitempty"`
+}
+
+// MasterAuth contains the authentication information of the cluster master.
+type MasterAuth struct {
+	// ClusterCaCertificate is the base64 encoded public certificate of the cluster root of trust.
+	ClusterCaCertificate string `json:"clusterCaCertificate,omitempty"`
+}
+
+// CreateClusterRequest is the body of a cluster create call.
+type CreateClusterRequest struct {
+	Cluster *Cluster `json:"cluster"`
+}
+
+// Operation is a long running operation started by the API.
+type Operation struct {
+	Name   string `json:"name"`
+	Status string `json:"status,omitempty"`
+}
+
+// Cluster status values.
+const (
+	StatusProvisioning = "PROVISIONING"
+	StatusRunning      = "RUNNING"
+	StatusReconciling  = "RECONCILING"
+	StatusStopping     = "STOPPING"
+	StatusError        = "ERROR"
+	StatusDegraded     = "DEGRADED"
+)
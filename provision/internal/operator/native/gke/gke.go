@@ -0,0 +1,252 @@
+package gke
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/kyma-project/hydroform/provision/internal/operator/native/poll"
+	"github.com/kyma-project/hydroform/provision/internal/operator/native/rest"
+	"github.com/kyma-project/hydroform/provision/types"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2/jwt"
+)
+
+const (
+	// DefaultEndpoint is the base URL of the GKE container REST API.
+	DefaultEndpoint    = "https://container.googleapis.com"
+	cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+	defaultTokenURL    = "https://oauth2.googleapis.com/token"
+	defaultPoolName    = "default-pool"
+)
+
+/*-- GKE native operator --*/
+
+func Create(ctx context.Context, ops *types.Options, cfg map[string]interface{}) (*types.ClusterInfo, error) {
+	client, err := clientFromCredentials(ctx, ops, cfg["credentials_file_path"].(string))
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the GKE client from credentials")
+	}
+	return client.Create(ctx, ops, cfg)
+}
+
+func Status(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) (*types.ClusterStatus, error) {
+	client, err := clientFromCredentials(ctx, ops, cfg["credentials_file_path"].(string))
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the GKE client from credentials")
+	}
+	return client.Status(ctx, ops, info, cfg)
+}
+
+func Delete(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) error {
+	client, err := clientFromCredentials(ctx, ops, cfg["credentials_file_path"].(string))
+	if err != nil {
+		return errors.Wrap(err, "error creating the GKE client from credentials")
+	}
+	return client.Delete(ctx, ops, info, cfg)
+}
+
+/*-- GKE client --*/
+
+// Client performs cluster operations against the GKE container REST API.
+type Client struct {
+	httpClient *http.Client
+	endpoint   string
+}
+
+// NewClient creates a GKE client that sends its requests with the given HTTP client to the given API endpoint.
+// The HTTP client is responsible for authenticating the requests.
+func NewClient(httpClient *http.Client, endpoint string) *Client {
+	return &Client{
+		httpClient: httpClient,
+		endpoint:   endpoint,
+	}
+}
+
+// clientFromCredentials creates a GKE client authenticated with the service account key in the given file.
+func clientFromCredentials(ctx context.Context, ops *types.Options, credentialsFile string) (*Client, error) {
+	data, err := os.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	key := struct {
+		ClientEmail  string `json:"client_email"`
+		PrivateKey   string `json:"private_key"`
+		PrivateKeyID string `json:"private_key_id"`
+		TokenURI     string `json:"token_uri"`
+	}{}
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, errors.Wrap(err, "credentials file is not a valid service account key")
+	}
+	if key.TokenURI == "" {
+		key.TokenURI = defaultTokenURL
+	}
+
+	conf := &jwt.Config{
+		Email:        key.ClientEmail,
+		PrivateKey:   []byte(key.PrivateKey),
+		PrivateKeyID: key.PrivateKeyID,
+		Scopes:       []string{cloudPlatformScope},
+		TokenURL:     key.TokenURI,
+	}
+	return NewClient(rest.WithLogging(conf.Client(ctx), ops.Log()), DefaultEndpoint), nil
+}
+
+// Create creates a new GKE cluster and waits until it is running.
+func (c *Client) Create(ctx context.Context, ops *types.Options, cfg map[string]interface{}) (*types.ClusterInfo, error) {
+	project, location, name := clusterPath(cfg)
+
+	req := &CreateClusterRequest{Cluster: toCluster(cfg)}
+	if err := rest.Do(ctx, c.httpClient, http.MethodPost, c.clustersURL(project, location), req, &Operation{}); err != nil {
+		return &types.ClusterInfo{
+			Status: &types.ClusterStatus{
+				Phase: types.Errored,
+			},
+		}, err
+	}
+
+	var cluster *Cluster
+	err := poll.Until(ctx, ops.Log(), types.CreateOperation, ops.PollingInterval(types.CreateOperation), ops.Timeout(types.CreateOperation),
+		func(ctx context.Context) (bool, error) {
+			var err error
+			if cluster, err = c.get(ctx, project, location, name); err != nil {
+				return false, err
+			}
+			if cluster.Status == StatusError {
+				return false, errors.Errorf("GKE cluster %s failed: %s", name, cluster.StatusMessage)
+			}
+			return cluster.Status == StatusRunning, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return toClusterInfo(cluster)
+}
+
+// Status returns the status of an existing GKE cluster.
+func (c *Client) Status(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) (*types.ClusterStatus, error) {
+	project, location, name := clusterPath(cfg)
+
+	cluster, err := c.get(ctx, project, location, name)
+	if err != nil {
+		return &types.ClusterStatus{
+			Phase: types.Errored,
+		}, err
+	}
+	return toClusterStatus(cluster), nil
+}
+
+// Delete deletes a GKE cluster and waits until it is gone.
+func (c *Client) Delete(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) error {
+	project, location, name := clusterPath(cfg)
+
+	if err := rest.Do(ctx, c.httpClient, http.MethodDelete, c.clusterURL(project, location, name), nil, &Operation{}); err != nil {
+		if rest.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	return poll.Until(ctx, ops.Log(), types.DeleteOperation, ops.PollingInterval(types.DeleteOperation), ops.Timeout(types.DeleteOperation),
+		func(ctx context.Context) (bool, error) {
+			_, err := c.get(ctx, project, location, name)
+			if rest.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		})
+}
+
+func (c *Client) get(ctx context.Context, project, location, name string) (*Cluster, error) {
+	cluster := &Cluster{}
+	if err := rest.Do(ctx, c.httpClient, http.MethodGet, c.clusterURL(project, location, name), nil, cluster); err != nil {
+		return nil, err
+	}
+	return cluster, nil
+}
+
+func (c *Client) clustersURL(project, location string) string {
+	return fmt.Sprintf("%s/v1/projects/%s/locations/%s/clusters", c.endpoint, project, location)
+}
+
+func (c *Client) clusterURL(project, location, name string) string {
+	return fmt.Sprintf("%s/%s", c.clustersURL(project, location), name)
+}
+
+/*-- Cluster building functions --*/
+
+func clusterPath(cfg map[string]interface{}) (project, location, name string) {
+	project, _ = cfg["project"].(string)
+	location, _ = cfg["location"].(string)
+	name, _ = cfg["cluster_name"].(string)
+	return
+}
+
+func toCluster(cfg map[string]interface{}) *Cluster {
+	cluster := &Cluster{}
+	pool := NodePool{
+		Name:   defaultPoolName,
+		Config: &NodeConfig{},
+	}
+
+	if v, ok := cfg["cluster_name"].(string); ok && len(v) > 0 {
+		cluster.Name = v
+	}
+	if v, ok := cfg["kubernetes_version"].(string); ok && len(v) > 0 {
+		cluster.InitialClusterVersion = v
+	}
+	if v, ok := cfg["node_count"].(int); ok && v > 0 {
+		pool.InitialNodeCount = v
+	}
+	if v, ok := cfg["machine_type"].(string); ok && len(v) > 0 {
+		pool.Config.MachineType = v
+	}
+	if v, ok := cfg["disk_size"].(int); ok && v > 0 {
+		pool.Config.DiskSizeGb = v
+	}
+	if v, ok := cfg["node_pool_name"].(string); ok && len(v) > 0 {
+		pool.Name = v
+	}
+
+	cluster.NodePools = append(cluster.NodePools, pool)
+	return cluster
+}
+
+func toClusterInfo(cluster *Cluster) (*types.ClusterInfo, error) {
+	info := &types.ClusterInfo{
+		Status: toClusterStatus(cluster),
+	}
+	if cluster.Endpoint != "" {
+		info.Endpoint = fmt.Sprintf("https://%s", cluster.Endpoint)
+	}
+	if cluster.MasterAuth != nil && cluster.MasterAuth.ClusterCaCertificate != "" {
+		ca, err := base64.StdEncoding.DecodeString(cluster.MasterAuth.ClusterCaCertificate)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode the cluster CA certificate")
+		}
+		info.CertificateAuthorityData = ca
+	}
+	return info, nil
+}
+
+func toClusterStatus(cluster *Cluster) *types.ClusterStatus {
+	switch cluster.Status {
+	case StatusProvisioning:
+		return &types.ClusterStatus{Phase: types.Provisioning}
+	case StatusRunning:
+		return &types.ClusterStatus{Phase: types.Provisioned}
+	case StatusReconciling:
+		return &types.ClusterStatus{Phase: types.Reconciling}
+	case StatusStopping:
+		return &types.ClusterStatus{Phase: types.Deprovisioning}
+	case StatusError, StatusDegraded:
+		return &types.ClusterStatus{Phase: types.Errored}
+	default:
+		return &types.ClusterStatus{Phase: types.Unknown}
+	}
+}
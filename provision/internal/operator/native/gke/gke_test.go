@@ -0,0 +1,140 @@
+package gke
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/hydroform/provision/internal/operator/native/rest/resttest"
+	"github.com/kyma-project/hydroform/provision/types"
+	"github.com/stretchr/testify/require"
+)
+
+const clustersPath = "/v1/projects/my-project/locations/europe-west3/clusters"
+
+// fakeGKE is an in-memory implementation of the GKE clusters API.
+// Clusters report PROVISIONING on the first GET after creation and RUNNING afterwards.
+type fakeGKE struct {
+	mu       sync.Mutex
+	clusters map[string]*Cluster
+	created  *Cluster
+}
+
+func newFakeGKE() *fakeGKE {
+	return &fakeGKE{clusters: map[string]*Cluster{}}
+}
+
+func (f *fakeGKE) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !strings.HasPrefix(r.URL.Path, clustersPath) {
+		resttest.WriteError(w, http.StatusNotFound)
+		return
+	}
+	name := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, clustersPath), "/")
+
+	switch {
+	case r.Method == http.MethodPost && name == "":
+		req := &CreateClusterRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			resttest.WriteError(w, http.StatusBadRequest)
+			return
+		}
+		f.created = req.Cluster
+		f.clusters[req.Cluster.Name] = &Cluster{Name: req.Cluster.Name, Status: StatusProvisioning}
+		resttest.WriteJSON(w, &Operation{Name: "operation-create", Status: "RUNNING"})
+	case r.Method == http.MethodGet:
+		c, ok := f.clusters[name]
+		if !ok {
+			resttest.WriteError(w, http.StatusNotFound)
+			return
+		}
+		resp := *c
+		if c.Status == StatusProvisioning {
+			c.Status = StatusRunning
+			c.Endpoint = "10.0.0.1"
+			c.MasterAuth = &MasterAuth{ClusterCaCertificate: base64.StdEncoding.EncodeToString([]byte("CA"))}
+		} else if c.Status == StatusStopping {
+			delete(f.clusters, name)
+		}
+		resttest.WriteJSON(w, &resp)
+	case r.Method == http.MethodDelete:
+		c, ok := f.clusters[name]
+		if !ok {
+			resttest.WriteError(w, http.StatusNotFound)
+			return
+		}
+		c.Status = StatusStopping
+		resttest.WriteJSON(w, &Operation{Name: "operation-delete", Status: "RUNNING"})
+	default:
+		resttest.WriteError(w, http.StatusMethodNotAllowed)
+	}
+}
+
+func testConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"cluster_name":       "hydro-cluster",
+		"node_count":         3,
+		"machine_type":       "n1-standard-4",
+		"disk_size":          50,
+		"kubernetes_version": "1.27",
+		"location":           "europe-west3",
+		"project":            "my-project",
+	}
+}
+
+func TestLifecycle(t *testing.T) {
+	t.Parallel()
+	fake := newFakeGKE()
+	srv := resttest.Server(t, fake)
+
+	resttest.Lifecycle(t, NewClient(srv.Client(), srv.URL), testConfig(), func(info *types.ClusterInfo) {
+		require.Equal(t, "https://10.0.0.1", info.Endpoint)
+		require.Equal(t, []byte("CA"), info.CertificateAuthorityData)
+
+		// check the cluster mapping
+		require.Equal(t, "hydro-cluster", fake.created.Name)
+		require.Equal(t, "1.27", fake.created.InitialClusterVersion)
+		require.Len(t, fake.created.NodePools, 1)
+		require.Equal(t, 3, fake.created.NodePools[0].InitialNodeCount)
+		require.Equal(t, "n1-standard-4", fake.created.NodePools[0].Config.MachineType)
+		require.Equal(t, 50, fake.created.NodePools[0].Config.DiskSizeGb)
+	})
+}
+
+func TestCreateTimeout(t *testing.T) {
+	t.Parallel()
+	// the cluster never becomes ready
+	srv := resttest.Server(t, resttest.Respond(&Cluster{Name: "hydro-cluster", Status: StatusProvisioning}))
+
+	ops := resttest.Options()
+	ops.Timeouts = &types.Timeouts{Create: 20 * time.Millisecond}
+
+	_, err := NewClient(srv.Client(), srv.URL).Create(context.Background(), ops, testConfig())
+	var timeoutErr *types.TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	require.Equal(t, types.CreateOperation, timeoutErr.Operation)
+}
+
+func TestCreateFailed(t *testing.T) {
+	t.Parallel()
+	resttest.CreateRejected(t, func(srv *httptest.Server) resttest.Client {
+		return NewClient(srv.Client(), srv.URL)
+	}, testConfig(), resttest.WriteError)
+}
+
+func TestToClusterNodePoolName(t *testing.T) {
+	t.Parallel()
+	cfg := testConfig()
+	require.Equal(t, defaultPoolName, toCluster(cfg).NodePools[0].Name)
+
+	cfg["node_pool_name"] = "system"
+	require.Equal(t, "system", toCluster(cfg).NodePools[0].Name)
+}
@@ -0,0 +1,375 @@
+package kind
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kyma-project/hydroform/provision/internal/operator/native/kubeconfig"
+	"github.com/kyma-project/hydroform/provision/internal/operator/native/poll"
+	"github.com/kyma-project/hydroform/provision/types"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+	"sigs.k8s.io/kind/pkg/cluster"
+)
+
+/*-- kind native operator --*/
+
+func Create(ctx context.Context, ops *types.Options, cfg map[string]interface{}) (*types.ClusterInfo, error) {
+	return NewClient(dockerProvider()).Create(ctx, ops, cfg)
+}
+
+func Status(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) (*types.ClusterStatus, error) {
+	return NewClient(dockerProvider()).Status(ctx, ops, info, cfg)
+}
+
+func Credentials(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) ([]byte, error) {
+	return NewClient(dockerProvider()).Credentials(ctx, ops, info, cfg)
+}
+
+func Delete(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) error {
+	return NewClient(dockerProvider()).Delete(ctx, ops, info, cfg)
+}
+
+/*-- kind client --*/
+
+// controlPlaneLabel selects the control plane nodes of a kind cluster.
+const controlPlaneLabel = "node-role.kubernetes.io/control-plane"
+
+// Provider is the subset of the kind cluster provider used by the operator.
+type Provider interface {
+	Create(name string, options ...cluster.CreateOption) error
+	Delete(name, explicitKubeconfigPath string) error
+	List() ([]string, error)
+	KubeConfig(name string, internal bool) (string, error)
+}
+
+func dockerProvider() Provider {
+	return cluster.NewProvider(cluster.ProviderWithDocker())
+}
+
+// Client manages kind clusters through a kind provider.
+type Client struct {
+	provider Provider
+}
+
+// NewClient creates a kind client on top of the given provider.
+func NewClient(p Provider) *Client {
+	return &Client{
+		provider: p,
+	}
+}
+
+// Create creates a new kind cluster and waits until its control plane nodes are ready.
+// The kind API is not cancellable, so the context is only checked before starting and bounds the readiness wait.
+// The create timeout covers both the creation of the nodes and the readiness wait.
+func (c *Client) Create(ctx context.Context, ops *types.Options, cfg map[string]interface{}) (*types.ClusterInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	name := clusterName(cfg)
+	kindCfg, err := toKindConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "error generating kind config")
+	}
+
+	kubeconfigPath := kubeconfigFile(ops, name)
+	if !ops.Persistent {
+		defer os.Remove(kubeconfigPath)
+	}
+
+	// kind only logs a warning if the cluster is not ready in time, so the readiness is awaited below instead
+	start := time.Now()
+	createOps := []cluster.CreateOption{
+		cluster.CreateWithV1Alpha4Config(kindCfg),
+		cluster.CreateWithKubeconfigPath(kubeconfigPath),
+		cluster.CreateWithDisplayUsage(false),
+		cluster.CreateWithDisplaySalutation(false),
+	}
+	if err := c.provider.Create(name, createOps...); err != nil {
+		return &types.ClusterInfo{
+			Status: &types.ClusterStatus{
+				Phase: types.Errored,
+			},
+		}, err
+	}
+
+	kc, err := c.provider.KubeConfig(name, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not fetch the kubeconfig")
+	}
+	endpoint, ca, err := kubeconfig.ClusterAccess([]byte(kc))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := waitForControlPlane(ctx, ops, []byte(kc), time.Since(start)); err != nil {
+		return nil, err
+	}
+
+	return &types.ClusterInfo{
+		Endpoint:                 endpoint,
+		CertificateAuthorityData: ca,
+		Status: &types.ClusterStatus{
+			Phase: types.Provisioned,
+		},
+	}, nil
+}
+
+// waitForControlPlane polls the cluster until all of its control plane nodes are ready.
+// elapsed is the time the creation of the nodes took, which counts towards the create timeout.
+func waitForControlPlane(ctx context.Context, ops *types.Options, kc []byte, elapsed time.Duration) error {
+	timeout := ops.Timeout(types.CreateOperation)
+	timeoutErr := &types.TimeoutError{Operation: types.CreateOperation, Timeout: timeout}
+	if elapsed >= timeout {
+		return timeoutErr
+	}
+
+	restCfg, err := clientcmd.RESTConfigFromKubeConfig(kc)
+	if err != nil {
+		return errors.Wrap(err, "could not read the kubeconfig")
+	}
+	client, err := kubernetes.NewForConfig(restCfg)
+	if err != nil {
+		return errors.Wrap(err, "could not create the client of the cluster")
+	}
+
+	err = poll.Until(ctx, ops.Log(), types.CreateOperation, ops.PollingInterval(types.CreateOperation), timeout-elapsed,
+		func(ctx context.Context) (bool, error) {
+			return controlPlaneReady(ctx, client), nil
+		})
+	var pollTimeout *types.TimeoutError
+	if errors.As(err, &pollTimeout) {
+		return timeoutErr
+	}
+	return err
+}
+
+// controlPlaneReady checks if the cluster has control plane nodes and all of them are ready.
+// Errors are treated as not ready, as the API server may still be starting.
+func controlPlaneReady(ctx context.Context, client kubernetes.Interface) bool {
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: controlPlaneLabel})
+	if err != nil || len(nodes.Items) == 0 {
+		return false
+	}
+	for _, n := range nodes.Items {
+		if !nodeReady(&n) {
+			return false
+		}
+	}
+	return true
+}
+
+func nodeReady(n *corev1.Node) bool {
+	for _, c := range n.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// Status returns the status of an existing kind cluster.
+func (c *Client) Status(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) (*types.ClusterStatus, error) {
+	name := clusterName(cfg)
+
+	clusters, err := c.provider.List()
+	if err != nil {
+		return &types.ClusterStatus{
+			Phase: types.Errored,
+		}, err
+	}
+	for _, cl := range clusters {
+		if cl == name {
+			return &types.ClusterStatus{
+				Phase: types.Provisioned,
+			}, nil
+		}
+	}
+	return &types.ClusterStatus{
+		Phase: types.Errored,
+	}, errors.Errorf("kind cluster %s not found", name)
+}
+
+// Credentials returns the kubeconfig of an existing kind cluster.
+func (c *Client) Credentials(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) ([]byte, error) {
+	kc, err := c.provider.KubeConfig(clusterName(cfg), false)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(kc), nil
+}
+
+// Delete deletes a kind cluster. Deleting a missing cluster is not an error.
+// The kind API is not cancellable: if the delete timeout expires or the context is done first,
+// Delete returns while kind keeps removing the nodes in the background.
+func (c *Client) Delete(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	name := clusterName(cfg)
+	kubeconfigPath := kubeconfigFile(ops, name)
+
+	done := make(chan error, 1)
+	go func() {
+		defer os.Remove(kubeconfigPath)
+		done <- c.provider.Delete(name, kubeconfigPath)
+	}()
+
+	timeout := ops.Timeout(types.DeleteOperation)
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		return &types.TimeoutError{Operation: types.DeleteOperation, Timeout: timeout}
+	case <-ctx.Done():
+		return errors.Wrapf(ctx.Err(), "%s operation aborted", types.DeleteOperation)
+	}
+}
+
+// kubeconfigFile is the file kind writes the cluster kubeconfig to.
+// It keeps kind from changing the kubeconfig of the user.
+func kubeconfigFile(ops *types.Options, name string) string {
+	dir := os.TempDir()
+	if ops != nil && ops.DataDir != "" {
+		dir = ops.DataDir
+	}
+	return filepath.Join(dir, fmt.Sprintf("kind-%s.kubeconfig", name))
+}
+
+/*-- Cluster building functions --*/
+
+func clusterName(cfg map[string]interface{}) string {
+	name, _ := cfg["cluster_name"].(string)
+	return name
+}
+
+// toKindConfig builds the kind cluster topology.
+// The cluster has control_plane_nodes control plane nodes (1 by default) and worker_nodes workers.
+// If worker_nodes is not set, the remaining nodes of node_count become workers.
+// The extra_port_mappings are added to the first control plane node.
+func toKindConfig(cfg map[string]interface{}) (*v1alpha4.Cluster, error) {
+	kindCfg := &v1alpha4.Cluster{
+		TypeMeta: v1alpha4.TypeMeta{
+			Kind:       "Cluster",
+			APIVersion: "kind.x-k8s.io/v1alpha4",
+		},
+	}
+
+	controlPlanes := 1
+	if v, ok := cfg["control_plane_nodes"].(int); ok {
+		if v < 1 {
+			return nil, errors.New("control_plane_nodes cannot be less than 1")
+		}
+		controlPlanes = v
+	}
+
+	workers := 0
+	if v, ok := cfg["worker_nodes"].(int); ok {
+		if v < 0 {
+			return nil, errors.New("worker_nodes cannot be less than 0")
+		}
+		workers = v
+	} else if v, ok := cfg["node_count"].(int); ok && v > controlPlanes {
+		workers = v - controlPlanes
+	}
+
+	image, _ := cfg["node_image"].(string)
+
+	mappings, err := portMappings(cfg["extra_port_mappings"])
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < controlPlanes; i++ {
+		n := v1alpha4.Node{
+			Role:  v1alpha4.ControlPlaneRole,
+			Image: image,
+		}
+		if i == 0 {
+			n.ExtraPortMappings = mappings
+		}
+		kindCfg.Nodes = append(kindCfg.Nodes, n)
+	}
+	for i := 0; i < workers; i++ {
+		kindCfg.Nodes = append(kindCfg.Nodes, v1alpha4.Node{
+			Role:  v1alpha4.WorkerRole,
+			Image: image,
+		})
+	}
+
+	return kindCfg, nil
+}
+
+// portMappings parses port mappings in the docker format "[listenAddress:]hostPort:containerPort[/protocol]".
+func portMappings(v interface{}) ([]v1alpha4.PortMapping, error) {
+	var specs []string
+	switch val := v.(type) {
+	case nil:
+		return nil, nil
+	case []string:
+		specs = val
+	case []interface{}:
+		for _, s := range val {
+			str, ok := s.(string)
+			if !ok {
+				return nil, errors.Errorf("extra_port_mappings entry %v is not a string", s)
+			}
+			specs = append(specs, str)
+		}
+	default:
+		return nil, errors.New("extra_port_mappings has to be a list of strings")
+	}
+
+	var mappings []v1alpha4.PortMapping
+	for _, spec := range specs {
+		m := v1alpha4.PortMapping{}
+
+		ports, protocol, hasProtocol := strings.Cut(spec, "/")
+		if hasProtocol {
+			switch strings.ToUpper(protocol) {
+			case string(v1alpha4.PortMappingProtocolTCP), string(v1alpha4.PortMappingProtocolUDP), string(v1alpha4.PortMappingProtocolSCTP):
+				m.Protocol = v1alpha4.PortMappingProtocol(strings.ToUpper(protocol))
+			default:
+				return nil, errors.Errorf("invalid protocol in port mapping %q", spec)
+			}
+		}
+
+		parts := strings.Split(ports, ":")
+		switch len(parts) {
+		case 2:
+		case 3:
+			m.ListenAddress = parts[0]
+			parts = parts[1:]
+		default:
+			return nil, errors.Errorf("invalid port mapping %q, expected [listenAddress:]hostPort:containerPort[/protocol]", spec)
+		}
+
+		hostPort, err := strconv.ParseInt(parts[0], 10, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid host port in port mapping %q", spec)
+		}
+		containerPort, err := strconv.ParseInt(parts[1], 10, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid container port in port mapping %q", spec)
+		}
+		m.HostPort = int32(hostPort)
+		m.ContainerPort = int32(containerPort)
+
+		mappings = append(mappings, m)
+	}
+	return mappings, nil
+}
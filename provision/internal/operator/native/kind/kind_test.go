@@ -0,0 +1,231 @@
+package kind
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/hydroform/provision/internal/operator/native/rest/resttest"
+	"github.com/kyma-project/hydroform/provision/types"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+	"sigs.k8s.io/kind/pkg/cluster"
+)
+
+const kubeconfigTemplate = `apiVersion: v1
+kind: Config
+clusters:
+- name: kind-hydro-cluster
+  cluster:
+    server: %s
+    certificate-authority-data: %s
+contexts:
+- name: kind-hydro-cluster
+  context:
+    cluster: kind-hydro-cluster
+    user: kind-hydro-cluster
+current-context: kind-hydro-cluster
+users:
+- name: kind-hydro-cluster
+  user:
+    token: secret
+`
+
+// fakeAPIServer starts an API server with a single control plane node that reports the given readiness
+// and returns the kubeconfig to access it.
+func fakeAPIServer(t *testing.T, ready bool) string {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/nodes" || r.URL.Query().Get("labelSelector") != controlPlaneLabel {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		resttest.WriteJSON(w, &corev1.NodeList{Items: []corev1.Node{{
+			ObjectMeta: metav1.ObjectMeta{Name: "hydro-cluster-control-plane"},
+			Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}}},
+		}}})
+	}))
+	t.Cleanup(srv.Close)
+
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	return fmt.Sprintf(kubeconfigTemplate, srv.URL, base64.StdEncoding.EncodeToString(ca))
+}
+
+// fakeProvider keeps the kind clusters in memory instead of running them in docker.
+type fakeProvider struct {
+	clusters   map[string]bool
+	createErr  error
+	kubeconfig string
+	// deleting blocks Delete until it is closed, if set
+	deleting chan struct{}
+}
+
+func (f *fakeProvider) Create(name string, options ...cluster.CreateOption) error {
+	if f.createErr != nil {
+		return f.createErr
+	}
+	f.clusters[name] = true
+	return nil
+}
+
+func (f *fakeProvider) Delete(name, explicitKubeconfigPath string) error {
+	if f.deleting != nil {
+		<-f.deleting
+	}
+	delete(f.clusters, name)
+	return nil
+}
+
+func (f *fakeProvider) List() ([]string, error) {
+	var names []string
+	for n := range f.clusters {
+		names = append(names, n)
+	}
+	return names, nil
+}
+
+func (f *fakeProvider) KubeConfig(name string, internal bool) (string, error) {
+	if !f.clusters[name] {
+		return "", errors.New("cluster not found")
+	}
+	return f.kubeconfig, nil
+}
+
+func TestLifecycle(t *testing.T) {
+	t.Parallel()
+	fake := &fakeProvider{clusters: map[string]bool{}, kubeconfig: fakeAPIServer(t, true)}
+	c := NewClient(fake)
+	ops := &types.Options{DataDir: t.TempDir(), PollingIntervals: &types.PollingIntervals{Create: time.Millisecond}}
+	cfg := map[string]interface{}{
+		"cluster_name": "hydro-cluster",
+		"node_image":   "kindest/node:v1.27.3",
+	}
+
+	info, err := c.Create(context.Background(), ops, cfg)
+	require.NoError(t, err)
+	require.Equal(t, types.Provisioned, info.Status.Phase)
+	require.True(t, strings.HasPrefix(info.Endpoint, "https://127.0.0.1:"))
+	require.Contains(t, string(info.CertificateAuthorityData), "BEGIN CERTIFICATE")
+
+	status, err := c.Status(context.Background(), ops, info, cfg)
+	require.NoError(t, err)
+	require.Equal(t, types.Provisioned, status.Phase)
+
+	kubeconfig, err := c.Credentials(context.Background(), ops, info, cfg)
+	require.NoError(t, err)
+	require.Equal(t, fake.kubeconfig, string(kubeconfig))
+
+	require.NoError(t, c.Delete(context.Background(), ops, info, cfg))
+
+	status, err = c.Status(context.Background(), ops, info, cfg)
+	require.Error(t, err)
+	require.Equal(t, types.Errored, status.Phase)
+}
+
+func TestCreateFailed(t *testing.T) {
+	t.Parallel()
+	c := NewClient(&fakeProvider{clusters: map[string]bool{}, createErr: errors.New("docker is not running")})
+
+	info, err := c.Create(context.Background(), &types.Options{DataDir: t.TempDir()}, map[string]interface{}{"cluster_name": "hydro-cluster"})
+	require.EqualError(t, err, "docker is not running")
+	require.Equal(t, types.Errored, info.Status.Phase)
+}
+
+func TestCreateTimeout(t *testing.T) {
+	t.Parallel()
+	c := NewClient(&fakeProvider{clusters: map[string]bool{}, kubeconfig: fakeAPIServer(t, false)})
+	ops := &types.Options{
+		DataDir:          t.TempDir(),
+		PollingIntervals: &types.PollingIntervals{Create: time.Millisecond},
+		Timeouts:         &types.Timeouts{Create: 50 * time.Millisecond},
+	}
+
+	_, err := c.Create(context.Background(), ops, map[string]interface{}{"cluster_name": "hydro-cluster"})
+	var timeoutErr *types.TimeoutError
+	require.ErrorAs(t, err, &timeoutErr, "A cluster that does not become ready should time out")
+	require.Equal(t, types.CreateOperation, timeoutErr.Operation)
+	require.Equal(t, 50*time.Millisecond, timeoutErr.Timeout)
+}
+
+func TestDeleteTimeout(t *testing.T) {
+	t.Parallel()
+	fake := &fakeProvider{clusters: map[string]bool{}, deleting: make(chan struct{})}
+	defer close(fake.deleting)
+	ops := &types.Options{DataDir: t.TempDir(), Timeouts: &types.Timeouts{Delete: 20 * time.Millisecond}}
+
+	err := NewClient(fake).Delete(context.Background(), ops, nil, map[string]interface{}{"cluster_name": "hydro-cluster"})
+	var timeoutErr *types.TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	require.Equal(t, types.DeleteOperation, timeoutErr.Operation)
+	require.Equal(t, 20*time.Millisecond, timeoutErr.Timeout)
+}
+
+func TestToKindConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Default topology", func(t *testing.T) {
+		t.Parallel()
+		cfg, err := toKindConfig(map[string]interface{}{})
+		require.NoError(t, err)
+		require.Len(t, cfg.Nodes, 1)
+		require.Equal(t, v1alpha4.ControlPlaneRole, cfg.Nodes[0].Role)
+	})
+
+	t.Run("Workers from node count", func(t *testing.T) {
+		t.Parallel()
+		cfg, err := toKindConfig(map[string]interface{}{
+			"node_count": 3,
+			"node_image": "kindest/node:v1.27.3",
+		})
+		require.NoError(t, err)
+		require.Len(t, cfg.Nodes, 3)
+		require.Equal(t, v1alpha4.ControlPlaneRole, cfg.Nodes[0].Role)
+		require.Equal(t, v1alpha4.WorkerRole, cfg.Nodes[1].Role)
+		require.Equal(t, v1alpha4.WorkerRole, cfg.Nodes[2].Role)
+		for _, n := range cfg.Nodes {
+			require.Equal(t, "kindest/node:v1.27.3", n.Image)
+		}
+	})
+
+	t.Run("Explicit topology with port mappings", func(t *testing.T) {
+		t.Parallel()
+		cfg, err := toKindConfig(map[string]interface{}{
+			"node_count":          10,
+			"control_plane_nodes": 3,
+			"worker_nodes":        2,
+			"extra_port_mappings": []interface{}{"80:30080", "127.0.0.1:443:30443/tcp"},
+		})
+		require.NoError(t, err)
+		require.Len(t, cfg.Nodes, 5)
+		require.Equal(t, []v1alpha4.PortMapping{
+			{HostPort: 80, ContainerPort: 30080},
+			{ListenAddress: "127.0.0.1", HostPort: 443, ContainerPort: 30443, Protocol: v1alpha4.PortMappingProtocolTCP},
+		}, cfg.Nodes[0].ExtraPortMappings)
+		require.Empty(t, cfg.Nodes[1].ExtraPortMappings)
+	})
+
+	t.Run("Invalid values", func(t *testing.T) {
+		t.Parallel()
+		_, err := toKindConfig(map[string]interface{}{"control_plane_nodes": 0})
+		require.Error(t, err)
+		_, err = toKindConfig(map[string]interface{}{"extra_port_mappings": []string{"80"}})
+		require.Error(t, err)
+		_, err = toKindConfig(map[string]interface{}{"extra_port_mappings": []string{"80:8080/http"}})
+		require.Error(t, err)
+		_, err = toKindConfig(map[string]interface{}{"extra_port_mappings": "80:8080"})
+		require.Error(t, err)
+	})
+}
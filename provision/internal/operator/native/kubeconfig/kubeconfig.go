@@ -0,0 +1,24 @@
+package kubeconfig
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// ClusterAccess returns the API server endpoint and the certificate authority of the cluster the current context of the kubeconfig points to.
+// If the kubeconfig has no current context, the first cluster is used.
+func ClusterAccess(kubeconfig []byte) (endpoint string, ca []byte, err error) {
+	cfg, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "could not parse the kubeconfig")
+	}
+	if kctx, ok := cfg.Contexts[cfg.CurrentContext]; ok {
+		if cluster, ok := cfg.Clusters[kctx.Cluster]; ok {
+			return cluster.Server, cluster.CertificateAuthorityData, nil
+		}
+	}
+	for _, cluster := range cfg.Clusters {
+		return cluster.Server, cluster.CertificateAuthorityData, nil
+	}
+	return "", nil, errors.New("the kubeconfig does not contain any cluster")
+}
@@ -1,7 +1,13 @@
 package native
 
 import (
+	"context"
+
+	"github.com/kyma-project/hydroform/provision/internal/operator/native/aks"
+	"github.com/kyma-project/hydroform/provision/internal/operator/native/eks"
 	"github.com/kyma-project/hydroform/provision/internal/operator/native/gardener"
+	"github.com/kyma-project/hydroform/provision/internal/operator/native/gke"
+	"github.com/kyma-project/hydroform/provision/internal/operator/native/kind"
 	"github.com/kyma-project/hydroform/provision/types"
 	"github.com/pkg/errors"
 )
@@ -20,32 +26,100 @@ func New(ops *types.Options) *Operator {
 }
 
 // Create creates a new cluster on the given provider based on the configuration and returns the same cluster enriched with its current state.
-func (o *Operator) Create(p types.ProviderType, cfg map[string]interface{}) (*types.ClusterInfo, error) {
+func (o *Operator) Create(ctx context.Context, p types.ProviderType, cfg map[string]interface{}) (*types.ClusterInfo, error) {
 	switch p {
 	case types.Gardener:
-		return gardener.Create(o.ops, cfg)
+		return gardener.Create(ctx, o.ops, cfg)
+	case types.GCP:
+		return gke.Create(ctx, o.ops, cfg)
+	case types.Azure:
+		return aks.Create(ctx, o.ops, cfg)
+	case types.AWS:
+		return eks.Create(ctx, o.ops, cfg)
+	case types.Kind:
+		return kind.Create(ctx, o.ops, cfg)
 	default:
 		return nil, errors.Errorf("Provider %s is not supported by the native operator", p)
 	}
 }
 
 // Status checks the cluster status based on the given state.
-// If the state is empty or nil, Status will attempt to load the state from the file system.
-func (o *Operator) Status(info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) (*types.ClusterStatus, error) {
+// The provision package loads a missing state from the state store before calling the operator.
+func (o *Operator) Status(ctx context.Context, info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) (*types.ClusterStatus, error) {
 	switch p {
 	case types.Gardener:
-		return gardener.Status(o.ops, info, cfg)
+		return gardener.Status(ctx, o.ops, info, cfg)
+	case types.GCP:
+		return gke.Status(ctx, o.ops, info, cfg)
+	case types.Azure:
+		return aks.Status(ctx, o.ops, info, cfg)
+	case types.AWS:
+		return eks.Status(ctx, o.ops, info, cfg)
+	case types.Kind:
+		return kind.Status(ctx, o.ops, info, cfg)
 	default:
 		return nil, errors.Errorf("Provider %s is not supported by the native operator", p)
 	}
 }
 
+// Credentials returns the kubeconfig of the cluster.
+func (o *Operator) Credentials(ctx context.Context, info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) ([]byte, error) {
+	switch p {
+	case types.Azure:
+		return aks.Credentials(ctx, o.ops, info, cfg)
+	case types.AWS:
+		return eks.Credentials(ctx, o.ops, info, cfg)
+	case types.Kind:
+		return kind.Credentials(ctx, o.ops, info, cfg)
+	default:
+		return nil, errors.Errorf("Credentials for provider %s are not supported by the native operator", p)
+	}
+}
+
+// Update changes an existing cluster to match the given configuration.
+func (o *Operator) Update(ctx context.Context, info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) (*types.ClusterInfo, error) {
+	switch p {
+	case types.Gardener:
+		return gardener.Update(ctx, o.ops, info, cfg)
+	default:
+		return nil, errors.Errorf("Update for provider %s is not supported by the native operator", p)
+	}
+}
+
+// Hibernate scales down an existing cluster.
+func (o *Operator) Hibernate(ctx context.Context, info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) (*types.ClusterInfo, error) {
+	switch p {
+	case types.Gardener:
+		return gardener.Hibernate(ctx, o.ops, info, cfg)
+	default:
+		return nil, errors.Errorf("Hibernation for provider %s is not supported by the native operator", p)
+	}
+}
+
+// WakeUp resumes a hibernated cluster.
+func (o *Operator) WakeUp(ctx context.Context, info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) (*types.ClusterInfo, error) {
+	switch p {
+	case types.Gardener:
+		return gardener.WakeUp(ctx, o.ops, info, cfg)
+	default:
+		return nil, errors.Errorf("Hibernation for provider %s is not supported by the native operator", p)
+	}
+}
+
 // Delete removes a cluster. For this operation a valid state is necessary.
-// If the state is empty or nil, Delete will attempt to load the state from the file system.
-func (o *Operator) Delete(info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) error {
+// The provision package loads a missing state from the state store before calling the operator.
+func (o *Operator) Delete(ctx context.Context, info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) error {
 	switch p {
 	case types.Gardener:
-		return gardener.Delete(o.ops, info, cfg)
+		return gardener.Delete(ctx, o.ops, info, cfg)
+	case types.GCP:
+		return gke.Delete(ctx, o.ops, info, cfg)
+	case types.Azure:
+		return aks.Delete(ctx, o.ops, info, cfg)
+	case types.AWS:
+		return eks.Delete(ctx, o.ops, info, cfg)
+	case types.Kind:
+		return kind.Delete(ctx, o.ops, info, cfg)
 	default:
 		return errors.Errorf("Provider %s is not supported by the native operator", p)
 	}
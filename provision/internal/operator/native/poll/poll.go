@@ -0,0 +1,60 @@
+package poll
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/kyma-project/hydroform/provision/types"
+	"github.com/pkg/errors"
+)
+
+// ConditionFunc reports whether the awaited state has been reached.
+// Returning an error stops the polling.
+type ConditionFunc func(ctx context.Context) (bool, error)
+
+// Until calls the condition every interval until it is satisfied, it fails, the timeout expires or the given context is done.
+// If the timeout expires, a *types.TimeoutError for the given operation is returned.
+// The result of each poll is logged at the debug level. The logger can be nil.
+func Until(ctx context.Context, log *slog.Logger, op types.Operation, interval, timeout time.Duration, condition ConditionFunc) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if log == nil {
+		log = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	log = log.With("operation", op)
+	attempt := 0
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			attempt++
+			done, err := condition(timeoutCtx)
+			log.Debug("polled", "attempt", attempt, "done", done, "error", err)
+			if err != nil {
+				if timeoutCtx.Err() != nil {
+					return contextError(ctx, op, timeout)
+				}
+				return err
+			}
+			if done {
+				return nil
+			}
+		case <-timeoutCtx.Done():
+			return contextError(ctx, op, timeout)
+		}
+	}
+}
+
+// contextError tells apart the operation timeout from a cancellation or deadline of the parent context.
+func contextError(parent context.Context, op types.Operation, timeout time.Duration) error {
+	if parent.Err() != nil {
+		return errors.Wrapf(parent.Err(), "%s operation aborted", op)
+	}
+	return &types.TimeoutError{Operation: op, Timeout: timeout}
+}
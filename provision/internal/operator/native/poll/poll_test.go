@@ -0,0 +1,57 @@
+package poll
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/hydroform/provision/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUntil(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Condition satisfied", func(t *testing.T) {
+		t.Parallel()
+		calls := 0
+		err := Until(context.Background(), nil, types.CreateOperation, time.Millisecond, time.Minute, func(ctx context.Context) (bool, error) {
+			calls++
+			return calls == 3, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, calls)
+	})
+
+	t.Run("Condition fails", func(t *testing.T) {
+		t.Parallel()
+		err := Until(context.Background(), nil, types.CreateOperation, time.Millisecond, time.Minute, func(ctx context.Context) (bool, error) {
+			return false, errors.New("API unavailable")
+		})
+		require.EqualError(t, err, "API unavailable")
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		t.Parallel()
+		err := Until(context.Background(), nil, types.DeleteOperation, time.Millisecond, 20*time.Millisecond, func(ctx context.Context) (bool, error) {
+			return false, nil
+		})
+		var timeoutErr *types.TimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		require.Equal(t, types.DeleteOperation, timeoutErr.Operation)
+		require.Equal(t, 20*time.Millisecond, timeoutErr.Timeout)
+	})
+
+	t.Run("Parent context cancelled", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := Until(ctx, nil, types.UpdateOperation, time.Millisecond, time.Minute, func(ctx context.Context) (bool, error) {
+			return false, nil
+		})
+		require.ErrorIs(t, err, context.Canceled)
+		var timeoutErr *types.TimeoutError
+		require.False(t, errors.As(err, &timeoutErr))
+	})
+}
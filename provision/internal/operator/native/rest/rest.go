@@ -0,0 +1,126 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Do sends a request with the given body encoded as JSON and decodes the JSON response into out.
+// Both in and out may be nil. Unsuccessful status codes are returned as *APIError.
+func Do(ctx context.Context, client *http.Client, method, url string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newAPIError(resp.StatusCode, data)
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+// APIError is returned when a cloud provider API answers with an unsuccessful status code.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error %d: %s", e.StatusCode, e.Message)
+}
+
+// newAPIError extracts the message of an error response.
+// Google and Azure APIs wrap the details in an "error" object, AWS APIs return the message at the top level.
+func newAPIError(code int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: code, Message: string(body)}
+
+	resp := struct {
+		Message string `json:"message"`
+		Error   struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}{}
+	if json.Unmarshal(body, &resp) == nil {
+		if resp.Error.Message != "" {
+			apiErr.Message = resp.Error.Message
+		} else if resp.Message != "" {
+			apiErr.Message = resp.Message
+		}
+	}
+	return apiErr
+}
+
+// IsNotFound checks if the error is an *APIError caused by a missing resource.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// WithLogging returns a copy of the client that logs each request and its result at the debug level.
+func WithLogging(client *http.Client, log *slog.Logger) *http.Client {
+	c := *client
+	c.Transport = LoggingTransport(client.Transport, log)
+	return &c
+}
+
+// LoggingTransport wraps the base transport, or http.DefaultTransport if it is nil, to log each request and its result at the debug level.
+// The headers are not logged, as they hold the credentials.
+func LoggingTransport(base http.RoundTripper, log *slog.Logger) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &loggingTransport{base: base, log: log}
+}
+
+type loggingTransport struct {
+	base http.RoundTripper
+	log  *slog.Logger
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+
+	attrs := []any{"method", req.Method, "url", req.URL.Redacted(), "duration", time.Since(start)}
+	if err != nil {
+		t.log.Debug("API call failed", append(attrs, "error", err)...)
+		return resp, err
+	}
+	t.log.Debug("API call", append(attrs, "status", resp.StatusCode)...)
+	return resp, nil
+}
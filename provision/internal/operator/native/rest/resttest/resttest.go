@@ -0,0 +1,106 @@
+// Package resttest holds the plumbing shared by the tests of the native operators that call a cloud provider REST API.
+// The tests run the operator clients against an httptest server that fakes the API.
+package resttest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/hydroform/provision/internal/operator/native/rest"
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+// Client is implemented by the clients of the native operators.
+type Client interface {
+	Create(ctx context.Context, ops *types.Options, cfg map[string]interface{}) (*types.ClusterInfo, error)
+	Status(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) (*types.ClusterStatus, error)
+	Delete(ctx context.Context, ops *types.Options, info *types.ClusterInfo, cfg map[string]interface{}) error
+}
+
+// Options returns options that poll every millisecond, so that the operations finish as soon as the fake API allows.
+func Options() *types.Options {
+	return &types.Options{
+		PollingIntervals: &types.PollingIntervals{
+			Create: time.Millisecond,
+			Update: time.Millisecond,
+			Delete: time.Millisecond,
+		},
+	}
+}
+
+// Server starts a server with the handler that is closed at the end of the test.
+func Server(t *testing.T, h http.Handler) *httptest.Server {
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// Respond returns a handler that answers every request with v encoded as JSON.
+func Respond(v interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		WriteJSON(w, v)
+	}
+}
+
+// WriteJSON writes v encoded as JSON.
+func WriteJSON(w http.ResponseWriter, v interface{}) {
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// WriteError writes an error response in the format of the Google and Azure APIs, which wrap the details in an "error" object.
+func WriteError(w http.ResponseWriter, code int) {
+	w.WriteHeader(code)
+	_, _ = fmt.Fprintf(w, `{"error": {"code": %d, "message": %q}}`, code, http.StatusText(code))
+}
+
+// WriteAWSError writes an error response in the format of the AWS APIs, which return the message at the top level.
+func WriteAWSError(w http.ResponseWriter, code int) {
+	w.WriteHeader(code)
+	_, _ = fmt.Fprintf(w, `{"message": %q}`, http.StatusText(code))
+}
+
+// Lifecycle creates a cluster with the client, reads its status, deletes it, and checks that it is gone.
+// The client has to talk to a fake API that provisions the cluster after a few polls.
+// check is called with the info of the created cluster for the assertions specific to the provider.
+func Lifecycle(t *testing.T, c Client, cfg map[string]interface{}, check func(info *types.ClusterInfo)) {
+	t.Helper()
+
+	info, err := c.Create(context.Background(), Options(), cfg)
+	require.NoError(t, err)
+	require.Equal(t, types.Provisioned, info.Status.Phase)
+	check(info)
+
+	status, err := c.Status(context.Background(), Options(), info, cfg)
+	require.NoError(t, err)
+	require.Equal(t, types.Provisioned, status.Phase)
+
+	require.NoError(t, c.Delete(context.Background(), Options(), info, cfg))
+
+	status, err = c.Status(context.Background(), Options(), info, cfg)
+	require.True(t, rest.IsNotFound(err), "The deleted cluster should not be found")
+	require.Equal(t, types.Errored, status.Phase)
+}
+
+// CreateRejected checks that a create request rejected by the API fails with the API error and an errored cluster.
+// newClient creates the client for the given server.
+func CreateRejected(t *testing.T, newClient func(srv *httptest.Server) Client, cfg map[string]interface{}, writeError func(w http.ResponseWriter, code int)) {
+	t.Helper()
+
+	srv := Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusForbidden)
+	}))
+
+	info, err := newClient(srv).Create(context.Background(), Options(), cfg)
+	var apiErr *rest.APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	require.Equal(t, http.StatusText(http.StatusForbidden), apiErr.Message)
+	require.Equal(t, types.Errored, info.Status.Phase)
+}
@@ -0,0 +1,53 @@
+package provision
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/kyma-project/hydroform/provision/lock"
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+// unlockTimeout bounds releasing a lock after the operation finished, even if the context of the operation is done.
+const unlockTimeout = 30 * time.Second
+
+// locker returns the configured locker or, by default, a file locker in the data directory.
+func locker(o *types.Options) types.Locker {
+	if o.Locker != nil {
+		return o.Locker
+	}
+	return lock.NewFileLocker(filepath.Join(o.Dir(), "locks"), lock.Config{Logger: o.Log()})
+}
+
+// lockCluster acquires the lock of the cluster and returns the context to run the operation with and the function that releases the lock.
+// If the locker notices that the lock is lost, the context is cancelled with ErrLockLost as its cause.
+func lockCluster(ctx context.Context, o *types.Options, cluster *types.Cluster, provider *types.Provider) (context.Context, func(), error) {
+	l := locker(o)
+	key := clusterKey(cluster, provider)
+	if err := l.Lock(ctx, key); err != nil {
+		return ctx, nil, err
+	}
+
+	opCtx, cancel := context.WithCancelCause(ctx)
+	if w, ok := l.(types.LockWatcher); ok {
+		if lost := w.Lost(key); lost != nil {
+			go func() {
+				select {
+				case <-lost:
+					cancel(fmt.Errorf("%w: %s", types.ErrLockLost, key))
+				case <-opCtx.Done():
+				}
+			}()
+		}
+	}
+
+	return opCtx, func() {
+		cancel(nil)
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), unlockTimeout)
+		defer cancel()
+		// a lock that cannot be released expires after its TTL
+		_ = l.Unlock(ctx, key)
+	}, nil
+}
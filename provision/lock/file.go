@@ -0,0 +1,208 @@
+package lock
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// FileLocker keeps each lock in a file in a directory. It prevents concurrent operations of processes sharing the directory.
+type FileLocker struct {
+	*locker
+}
+
+// NewFileLocker creates a locker that keeps the lock files in the given directory. The directory is created when the first lock is acquired.
+func NewFileLocker(dir string, cfg Config) *FileLocker {
+	return &FileLocker{
+		locker: newLocker(&fileBackend{dir: dir}, cfg),
+	}
+}
+
+// lockRecord is the content of a lock file.
+type lockRecord struct {
+	Holder     string        `json:"holder"`
+	TTL        time.Duration `json:"ttl"`
+	RenewTime  time.Time     `json:"renewTime"`
+	AcquiredAt time.Time     `json:"acquiredAt"`
+}
+
+func (r *lockRecord) stale(now time.Time) bool {
+	return now.After(r.RenewTime.Add(r.TTL))
+}
+
+type fileBackend struct {
+	dir string
+}
+
+func (f *fileBackend) file(key string) string {
+	return filepath.Join(f.dir, url.PathEscape(key)+".lock")
+}
+
+func (f *fileBackend) tryLock(ctx context.Context, key, holder string, ttl time.Duration) (bool, string, error) {
+	if err := os.MkdirAll(f.dir, 0700); err != nil {
+		return false, "", err
+	}
+	path := f.file(key)
+
+	now := time.Now()
+	data, err := json.Marshal(&lockRecord{Holder: holder, TTL: ttl, RenewTime: now, AcquiredAt: now})
+	if err != nil {
+		return false, "", err
+	}
+
+	// creating the file fails if it exists, so only one process can acquire the lock
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err == nil {
+		_, err = file.Write(data)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(path)
+			return false, "", err
+		}
+		return true, holder, nil
+	}
+	if !os.IsExist(err) {
+		return false, "", err
+	}
+
+	current, raw, err := readRecord(path)
+	if os.IsNotExist(err) {
+		// released in the meantime
+		return false, "", nil
+	}
+	if err != nil {
+		return false, "", err
+	}
+	if current == nil {
+		// a lock file that cannot be read, for example because its holder crashed while writing it, expires with its modification time
+		info, err := os.Stat(path)
+		if err != nil {
+			return false, "", nil
+		}
+		current = &lockRecord{Holder: "unknown", TTL: ttl, RenewTime: info.ModTime()}
+	}
+	if !current.stale(now) {
+		return false, current.Holder, nil
+	}
+
+	// take over the stale lock by moving it out of the way. Renaming is atomic, so only one process moves the file.
+	// The moved file is checked afterwards, as another process may have replaced the stale lock after it was read.
+	moved, err := f.moveAside(path, raw)
+	if err != nil || !moved {
+		return false, current.Holder, err
+	}
+	return f.tryLock(ctx, key, holder, ttl)
+}
+
+func (f *fileBackend) renew(_ context.Context, key, holder string) error {
+	path := f.file(key)
+	current, raw, err := readRecord(path)
+	if os.IsNotExist(err) {
+		return errors.Wrapf(errNotHeld, "lock %s was released", key)
+	}
+	if err != nil {
+		return err
+	}
+	if current == nil || current.Holder != holder {
+		return errors.Wrapf(errNotHeld, "lock %s is held by someone else", key)
+	}
+
+	current.RenewTime = time.Now()
+	data, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+
+	// the renewed lock is written completely before it is linked in place, so that no one reads a partially written lock
+	tmp, err := os.CreateTemp(f.dir, filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	// replace the lock only if it is unchanged since it was read
+	moved, err := f.moveAside(path, raw)
+	if err != nil {
+		return err
+	}
+	if !moved {
+		return errors.Wrapf(errNotHeld, "lock %s was taken over", key)
+	}
+	if err := os.Link(tmp.Name(), path); err != nil {
+		if os.IsExist(err) {
+			return errors.Wrapf(errNotHeld, "lock %s was taken over", key)
+		}
+		return err
+	}
+	return nil
+}
+
+// moveAside removes the lock file atomically if its content is still raw. It reports if the file was removed.
+// The file is renamed to a unique name first, so that no other process can change it while its content is checked.
+// A file that was changed in the meantime is put back, unless yet another lock was created in its place.
+// The holder of that lock then fails to renew it and stops its operation.
+func (f *fileBackend) moveAside(path string, raw []byte) (bool, error) {
+	aside := path + ".stale-" + randomSuffix()
+	if err := os.Rename(path, aside); err != nil {
+		if os.IsNotExist(err) {
+			// moved or released by someone else
+			return false, nil
+		}
+		return false, err
+	}
+	defer os.Remove(aside)
+
+	latest, err := os.ReadFile(aside)
+	if err == nil && bytes.Equal(latest, raw) {
+		return true, nil
+	}
+	_ = os.Link(aside, path)
+	return false, nil
+}
+
+func (f *fileBackend) unlock(_ context.Context, key, holder string) error {
+	path := f.file(key)
+	current, _, err := readRecord(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if current == nil || current.Holder != holder {
+		// the lock expired and was taken over, it is not ours to release
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// readRecord reads a lock file. The record is nil if the file cannot be decoded.
+func readRecord(path string) (*lockRecord, []byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := &lockRecord{}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, data, nil
+	}
+	return r, data, nil
+}
@@ -0,0 +1,101 @@
+package lock
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+func TestFileLocker(t *testing.T) {
+	t.Parallel()
+	dir := filepath.Join(t.TempDir(), "locks")
+	testLocker(t, func(cfg Config) types.Locker {
+		return NewFileLocker(dir, cfg)
+	})
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries, "Released locks should not leave files behind")
+}
+
+func TestFileLockerStale(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	testStaleLock(t, 300*time.Millisecond, func(cfg Config) types.Locker {
+		return NewFileLocker(dir, cfg)
+	}, stopRenewal)
+}
+
+func TestFileLockerUnreadable(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	l := NewFileLocker(dir, Config{Timeout: 50 * time.Millisecond, RetryInterval: 10 * time.Millisecond, TTL: time.Minute})
+
+	// a lock file left behind half-written
+	path := filepath.Join(dir, "hydro.lock")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0600))
+	require.ErrorIs(t, l.Lock(context.Background(), "hydro"), types.ErrLocked, "A recent unreadable lock should be respected")
+
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(path, old, old))
+	require.NoError(t, l.Lock(context.Background(), "hydro"), "An old unreadable lock should be taken over")
+}
+
+func TestFileLockerMoveAside(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	f := &fileBackend{dir: dir}
+	path := filepath.Join(dir, "hydro.lock")
+
+	// a stale lock that was replaced after it was read is put back
+	require.NoError(t, os.WriteFile(path, []byte("new"), 0600))
+	moved, err := f.moveAside(path, []byte("old"))
+	require.NoError(t, err)
+	require.False(t, moved)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "new", string(data), "The lock of the other process should be put back")
+
+	moved, err = f.moveAside(path, []byte("new"))
+	require.NoError(t, err)
+	require.True(t, moved)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries, "The moved lock should be removed")
+
+	moved, err = f.moveAside(path, []byte("new"))
+	require.NoError(t, err)
+	require.False(t, moved, "A missing lock cannot be moved")
+}
+
+func TestFileLockerLost(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dir := t.TempDir()
+	l := NewFileLocker(dir, Config{Holder: "first", TTL: 150 * time.Millisecond})
+	require.Nil(t, l.Lost("hydro"), "A lock that is not held cannot be lost")
+
+	require.NoError(t, l.Lock(ctx, "hydro"))
+	lost := l.Lost("hydro")
+	require.NotNil(t, lost)
+
+	// another process takes over the lock
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "hydro.lock"), []byte(`{"holder":"second","ttl":60000000000}`), 0600))
+	require.ErrorIs(t, l.backend.renew(ctx, "hydro", "first"), errNotHeld)
+
+	select {
+	case <-lost:
+	case <-time.After(time.Second):
+		t.Fatal("The lost lock should be reported")
+	}
+	require.NoError(t, l.Unlock(ctx, "hydro"))
+	data, err := os.ReadFile(filepath.Join(dir, "hydro.lock"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "second", "The lock of the other holder should not be released")
+}
@@ -0,0 +1,158 @@
+package lock
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	leasePrefix = "hydroform-"
+	// keyAnnotation is the annotation holding the lock key, as the Lease name only contains a sanitized form of it.
+	keyAnnotation = "hydroform.kyma-project.io/lock-key"
+)
+
+var invalidLeaseChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// LeaseLocker keeps each lock in a Kubernetes Lease. It prevents concurrent operations of processes on different machines,
+// such as CI jobs, that have access to the same cluster.
+type LeaseLocker struct {
+	*locker
+}
+
+// NewLeaseLocker creates a locker that keeps the locks as Leases in the given namespace.
+func NewLeaseLocker(client kubernetes.Interface, namespace string, cfg Config) *LeaseLocker {
+	return &LeaseLocker{
+		locker: newLocker(&leaseBackend{client: client, namespace: namespace}, cfg),
+	}
+}
+
+type leaseBackend struct {
+	client    kubernetes.Interface
+	namespace string
+}
+
+// leaseName turns the key into a valid object name. The hash keeps keys that only differ in invalid characters apart.
+func leaseName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	name := strings.Trim(invalidLeaseChars.ReplaceAllString(strings.ToLower(key), "-"), "-")
+	if len(name) > 40 {
+		name = name[:40]
+	}
+	return leasePrefix + name + "-" + hex.EncodeToString(sum[:])[:10]
+}
+
+func (l *leaseBackend) tryLock(ctx context.Context, key, holder string, ttl time.Duration) (bool, string, error) {
+	leases := l.client.CoordinationV1().Leases(l.namespace)
+	now := metav1.NewMicroTime(time.Now())
+	seconds := int32(math.Ceil(ttl.Seconds()))
+
+	lease, err := leases.Get(ctx, leaseName(key), metav1.GetOptions{})
+	if k8sErrors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        leaseName(key),
+				Namespace:   l.namespace,
+				Annotations: map[string]string{keyAnnotation: key},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &holder,
+				LeaseDurationSeconds: &seconds,
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		_, err = leases.Create(ctx, lease, metav1.CreateOptions{})
+		if k8sErrors.IsAlreadyExists(err) {
+			// someone else was faster
+			return false, "", nil
+		}
+		return err == nil, holder, err
+	}
+	if err != nil {
+		return false, "", err
+	}
+
+	current := ""
+	if lease.Spec.HolderIdentity != nil {
+		current = *lease.Spec.HolderIdentity
+	}
+	if current != "" && current != holder && !leaseExpired(lease, now.Time) {
+		return false, current, nil
+	}
+
+	// take over the free or stale lease, the update fails if it changed in the meantime
+	lease.Spec.HolderIdentity = &holder
+	lease.Spec.LeaseDurationSeconds = &seconds
+	lease.Spec.AcquireTime = &now
+	lease.Spec.RenewTime = &now
+	_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	if k8sErrors.IsConflict(err) {
+		return false, current, nil
+	}
+	return err == nil, holder, err
+}
+
+func leaseExpired(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	return now.After(lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second))
+}
+
+func (l *leaseBackend) renew(ctx context.Context, key, holder string) error {
+	leases := l.client.CoordinationV1().Leases(l.namespace)
+	lease, err := leases.Get(ctx, leaseName(key), metav1.GetOptions{})
+	if k8sErrors.IsNotFound(err) {
+		return errors.Wrapf(errNotHeld, "lease %s was deleted", leaseName(key))
+	}
+	if err != nil {
+		return err
+	}
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != holder {
+		return errors.Wrapf(errNotHeld, "lease %s is held by someone else", lease.Name)
+	}
+
+	// the update fails if the lease was taken over since it was read
+	now := metav1.NewMicroTime(time.Now())
+	lease.Spec.RenewTime = &now
+	_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	if k8sErrors.IsConflict(err) {
+		return errors.Wrapf(errNotHeld, "lease %s was changed by someone else", lease.Name)
+	}
+	return err
+}
+
+func (l *leaseBackend) unlock(ctx context.Context, key, holder string) error {
+	leases := l.client.CoordinationV1().Leases(l.namespace)
+	lease, err := leases.Get(ctx, leaseName(key), metav1.GetOptions{})
+	if k8sErrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != holder {
+		// the lease expired and was taken over, it is not ours to release
+		return nil
+	}
+
+	// only delete the lease in the version we hold
+	err = leases.Delete(ctx, lease.Name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{ResourceVersion: &lease.ResourceVersion},
+	})
+	if k8sErrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
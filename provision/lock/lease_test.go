@@ -0,0 +1,42 @@
+package lock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sFake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+func TestLeaseLocker(t *testing.T) {
+	t.Parallel()
+	client := k8sFake.NewSimpleClientset()
+	testLocker(t, func(cfg Config) types.Locker {
+		return NewLeaseLocker(client, "pipelines", cfg)
+	})
+
+	leases, err := client.CoordinationV1().Leases("pipelines").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Empty(t, leases.Items, "Released locks should not leave leases behind")
+}
+
+func TestLeaseLockerStale(t *testing.T) {
+	t.Parallel()
+	client := k8sFake.NewSimpleClientset()
+	// leases have a resolution of seconds
+	testStaleLock(t, 1500*time.Millisecond, func(cfg Config) types.Locker {
+		return NewLeaseLocker(client, "pipelines", cfg)
+	}, stopRenewal)
+}
+
+func TestLeaseName(t *testing.T) {
+	t.Parallel()
+	name := leaseName("gardener/My_Project/hydro")
+	require.Regexp(t, `^hydroform-gardener-my-project-hydro-[0-9a-f]{10}$`, name)
+	require.NotEqual(t, name, leaseName("gardener/my-project/hydro"), "Keys that only differ in invalid characters should not share a lease")
+	require.LessOrEqual(t, len(leaseName(string(make([]byte, 300)))), 63)
+}
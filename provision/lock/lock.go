@@ -0,0 +1,220 @@
+// Package lock provides the lockers Hydroform can use to prevent concurrent changes of the same cluster.
+//
+// A held lock is renewed in the background until it is released. A lock that has not been renewed within its TTL,
+// for example because its holder crashed, is stale and is taken over by the next operation.
+package lock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+const (
+	// DefaultTimeout is how long Lock waits for a held lock by default.
+	DefaultTimeout = 5 * time.Minute
+	// DefaultTTL is how long a lock stays valid without being renewed by default.
+	DefaultTTL = 2 * time.Minute
+	// DefaultRetryInterval is how often a held lock is checked while waiting for it by default.
+	DefaultRetryInterval = 2 * time.Second
+)
+
+// Config specifies how locks are acquired and kept.
+type Config struct {
+	// Timeout is how long Lock waits while the lock is held by someone else. Defaults to DefaultTimeout.
+	Timeout time.Duration
+	// TTL is how long a lock stays valid without being renewed. The lock is renewed every third of the TTL. Defaults to DefaultTTL.
+	TTL time.Duration
+	// RetryInterval is how often a held lock is checked while waiting for it. Defaults to DefaultRetryInterval.
+	RetryInterval time.Duration
+	// Holder identifies the owner of the locks. Defaults to the host name, the process ID, and a random suffix.
+	Holder string
+	// Logger receives the logs of waiting for and renewing locks. By default, nothing is logged.
+	Logger *slog.Logger
+}
+
+func (c Config) withDefaults() Config {
+	if c.Timeout <= 0 {
+		c.Timeout = DefaultTimeout
+	}
+	if c.TTL <= 0 {
+		c.TTL = DefaultTTL
+	}
+	if c.RetryInterval <= 0 {
+		c.RetryInterval = DefaultRetryInterval
+	}
+	if c.Holder == "" {
+		c.Holder = defaultHolder()
+	}
+	if c.Logger == nil {
+		c.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return c
+}
+
+func defaultHolder() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), randomSuffix())
+}
+
+// randomSuffix returns a random string to make names unique.
+func randomSuffix() string {
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	return hex.EncodeToString(suffix)
+}
+
+// errNotHeld is returned by a backend that cannot renew a lock because it is no longer held by the holder.
+var errNotHeld = errors.New("lock is no longer held")
+
+// backend stores the locks. Implementations only need to handle a single attempt, the waiting and renewing is done by the locker.
+type backend interface {
+	// tryLock acquires the lock if it is free, stale, or already held by the holder. Otherwise, it returns the current holder.
+	tryLock(ctx context.Context, key, holder string, ttl time.Duration) (acquired bool, current string, err error)
+	// renew extends a lock held by the holder. It fails with errNotHeld if the lock was released or taken over.
+	renew(ctx context.Context, key, holder string) error
+	// unlock releases a lock held by the holder.
+	unlock(ctx context.Context, key, holder string) error
+}
+
+// locker implements types.Locker on top of a backend.
+type locker struct {
+	backend backend
+	cfg     Config
+
+	mu       sync.Mutex
+	renewals map[string]*renewal
+}
+
+// renewal keeps a held lock alive.
+type renewal struct {
+	cancel context.CancelFunc
+	// lost is closed when the lock could not be renewed.
+	lost chan struct{}
+}
+
+func newLocker(b backend, cfg Config) *locker {
+	return &locker{
+		backend:  b,
+		cfg:      cfg.withDefaults(),
+		renewals: map[string]*renewal{},
+	}
+}
+
+// Lock acquires the lock with the given key and keeps renewing it until Unlock is called.
+func (l *locker) Lock(ctx context.Context, key string) error {
+	timeout := time.NewTimer(l.cfg.Timeout)
+	defer timeout.Stop()
+
+	for {
+		acquired, current, err := l.tryLock(ctx, key)
+		if err != nil {
+			return errors.Wrapf(err, "unable to acquire the lock %s", key)
+		}
+		if acquired {
+			l.cfg.Logger.Debug("lock acquired", "key", key)
+			return nil
+		}
+		l.cfg.Logger.Debug("waiting for the lock", "key", key, "holder", current, "retryInterval", l.cfg.RetryInterval)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout.C:
+			return fmt.Errorf("%w: lock %s is held by %s", types.ErrLocked, key, current)
+		case <-time.After(l.cfg.RetryInterval):
+		}
+	}
+}
+
+// tryLock makes a single attempt to acquire the lock.
+// Locks held by other goroutines of this process are checked first, as the backend cannot tell them apart.
+func (l *locker) tryLock(ctx context.Context, key string) (bool, string, error) {
+	l.mu.Lock()
+	if _, held := l.renewals[key]; held {
+		l.mu.Unlock()
+		return false, l.cfg.Holder, nil
+	}
+	renewCtx, cancel := context.WithCancel(context.Background())
+	r := &renewal{cancel: cancel, lost: make(chan struct{})}
+	l.renewals[key] = r
+	l.mu.Unlock()
+
+	acquired, current, err := l.backend.tryLock(ctx, key, l.cfg.Holder, l.cfg.TTL)
+	if err != nil || !acquired {
+		l.mu.Lock()
+		delete(l.renewals, key)
+		l.mu.Unlock()
+		cancel()
+		return false, current, err
+	}
+
+	go l.renew(renewCtx, key, r.lost)
+	return true, current, nil
+}
+
+// Unlock stops renewing the lock with the given key and releases it.
+func (l *locker) Unlock(ctx context.Context, key string) error {
+	l.mu.Lock()
+	r, held := l.renewals[key]
+	delete(l.renewals, key)
+	l.mu.Unlock()
+	if !held {
+		return nil
+	}
+
+	r.cancel()
+	return errors.Wrapf(l.backend.unlock(ctx, key, l.cfg.Holder), "unable to release the lock %s", key)
+}
+
+// Lost returns a channel that is closed when the lock with the given key could not be renewed. It returns nil if the lock is not held.
+func (l *locker) Lost(key string) <-chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if r, held := l.renewals[key]; held {
+		return r.lost
+	}
+	return nil
+}
+
+// renew extends the lock every third of its TTL until the context is cancelled.
+// It closes lost and stops once the lock is held by someone else or was not renewed within its TTL.
+func (l *locker) renew(ctx context.Context, key string, lost chan struct{}) {
+	ticker := time.NewTicker(l.cfg.TTL / 3)
+	defer ticker.Stop()
+	renewed := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := l.backend.renew(ctx, key, l.cfg.Holder)
+			switch {
+			case err == nil:
+				renewed = time.Now()
+			case ctx.Err() != nil:
+				return
+			case errors.Is(err, errNotHeld) || time.Since(renewed) >= l.cfg.TTL:
+				l.cfg.Logger.Error("lost the lock", "key", key, "error", err)
+				close(lost)
+				return
+			default:
+				// a failed renewal is retried on the next tick, the lock only expires after the full TTL
+				l.cfg.Logger.Warn("unable to renew the lock", "key", key, "error", err)
+			}
+		}
+	}
+}
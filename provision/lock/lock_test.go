@@ -0,0 +1,109 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+// testLocker runs the checks every locker has to pass. The lockers have to share the same storage.
+func testLocker(t *testing.T, newLocker func(cfg Config) types.Locker) {
+	ctx := context.Background()
+	first := newLocker(Config{Holder: "first", Timeout: 100 * time.Millisecond, RetryInterval: 10 * time.Millisecond})
+	second := newLocker(Config{Holder: "second", Timeout: 100 * time.Millisecond, RetryInterval: 10 * time.Millisecond})
+
+	require.NoError(t, first.Lock(ctx, "gardener/project/hydro"))
+	require.NoError(t, second.Lock(ctx, "gardener/project/other"), "Locks of other clusters should not be affected")
+
+	err := second.Lock(ctx, "gardener/project/hydro")
+	require.True(t, errors.Is(err, types.ErrLocked), "Locking a held lock should time out with ErrLocked")
+	require.Contains(t, err.Error(), "first")
+
+	require.NoError(t, second.Unlock(ctx, "gardener/project/hydro"), "Releasing a lock held by someone else should do nothing")
+	err = second.Lock(ctx, "gardener/project/hydro")
+	require.True(t, errors.Is(err, types.ErrLocked), "The lock should still be held")
+
+	// waiting for a lock that is released in the meantime
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		_ = first.Unlock(ctx, "gardener/project/hydro")
+	}()
+	require.NoError(t, second.Lock(ctx, "gardener/project/hydro"))
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.ErrorIs(t, first.Lock(cancelled, "gardener/project/hydro"), context.Canceled)
+
+	require.NoError(t, second.Unlock(ctx, "gardener/project/hydro"))
+	require.NoError(t, second.Unlock(ctx, "gardener/project/other"))
+	require.NoError(t, first.Lock(ctx, "gardener/project/hydro"))
+	require.NoError(t, first.Unlock(ctx, "gardener/project/hydro"))
+}
+
+// testStaleLock checks that a lock is kept alive while it is held and taken over once its holder stops renewing it.
+func testStaleLock(t *testing.T, ttl time.Duration, newLocker func(cfg Config) types.Locker, crash func(l types.Locker)) {
+	ctx := context.Background()
+	first := newLocker(Config{Holder: "first", TTL: ttl})
+	second := newLocker(Config{Holder: "second", TTL: ttl, Timeout: ttl / 2, RetryInterval: ttl / 10})
+
+	require.NoError(t, first.Lock(ctx, "hydro"))
+	time.Sleep(ttl * 3 / 2)
+	require.ErrorIs(t, second.Lock(ctx, "hydro"), types.ErrLocked, "A renewed lock should not expire")
+
+	crash(first)
+	second = newLocker(Config{Holder: "second", TTL: ttl, Timeout: 3 * ttl, RetryInterval: ttl / 10})
+	require.NoError(t, second.Lock(ctx, "hydro"), "A stale lock should be taken over")
+	require.NoError(t, second.Unlock(ctx, "hydro"))
+}
+
+// stopRenewal simulates a crashed holder.
+func stopRenewal(l types.Locker) {
+	var lk *locker
+	switch v := l.(type) {
+	case *FileLocker:
+		lk = v.locker
+	case *LeaseLocker:
+		lk = v.locker
+	}
+	lk.mu.Lock()
+	defer lk.mu.Unlock()
+	for _, r := range lk.renewals {
+		r.cancel()
+	}
+}
+
+func TestLockWithinProcess(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	l := NewFileLocker(t.TempDir(), Config{RetryInterval: time.Millisecond})
+
+	// goroutines sharing a locker have the same holder, so the locker has to keep them apart
+	var mu sync.Mutex
+	running := 0
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, l.Lock(ctx, "hydro"))
+			mu.Lock()
+			running++
+			require.Equal(t, 1, running, "Only one goroutine should hold the lock")
+			mu.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+			require.NoError(t, l.Unlock(ctx, "hydro"))
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,40 @@
+// Package operator defines the operators that run the provider-specific operations of Hydroform.
+// Operators other than the native one can be plugged in with Register and selected per call with types.WithOperator.
+package operator
+
+import (
+	"context"
+
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+//go:generate mockery --name=Operator --case=snake --output=../internal/operator/mocks
+
+// Operator allows switching easily between different types of provisioning operators.
+// All operations receive a context that can be used to cancel them or to bound their runtime.
+type Operator interface {
+	// Create creates a new cluster on the given provider based on the configuration and returns the same cluster enriched with its current state.
+	Create(ctx context.Context, p types.ProviderType, cfg map[string]interface{}) (*types.ClusterInfo, error)
+	// Status checks the cluster status based on the given state.
+	// The provision package loads a missing state from the state store before calling the operator.
+	Status(ctx context.Context, info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) (*types.ClusterStatus, error)
+	// Credentials returns the kubeconfig of the cluster.
+	Credentials(ctx context.Context, info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) ([]byte, error)
+	// Update changes an existing cluster to match the configuration and returns the cluster enriched with its new state.
+	Update(ctx context.Context, info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) (*types.ClusterInfo, error)
+	// Hibernate scales down an existing cluster and returns the cluster enriched with its new state.
+	Hibernate(ctx context.Context, info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) (*types.ClusterInfo, error)
+	// WakeUp resumes a hibernated cluster and returns the cluster enriched with its new state.
+	WakeUp(ctx context.Context, info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) (*types.ClusterInfo, error)
+	// Delete removes a cluster. For this operation a valid state is necessary.
+	// The provision package loads a missing state from the state store before calling the operator.
+	Delete(ctx context.Context, info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) error
+}
+
+// Type points out the type of the operator.
+type Type = types.OperatorType
+
+const (
+	// NativeOperator calls the provider APIs directly. It is used unless another operator is selected.
+	NativeOperator Type = "native"
+)
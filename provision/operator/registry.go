@@ -0,0 +1,47 @@
+package operator
+
+import (
+	"sync"
+
+	"github.com/kyma-project/hydroform/provision/internal/operator/native"
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+// Factory creates an operator for the options of a Hydroform call.
+type Factory func(ops *types.Options) Operator
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[Type]Factory{
+		NativeOperator: func(ops *types.Options) Operator {
+			return native.New(ops)
+		},
+	}
+)
+
+// Register makes the operator created by the factory available under the given type.
+// Registering a type again replaces its factory, which also allows replacing the native operator.
+func Register(t Type, f Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[t] = f
+}
+
+// Unregister removes the operator of the given type.
+func Unregister(t Type) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	delete(registry, t)
+}
+
+// New creates an operator of the given type.
+// If no operator is registered for the type, the Unknown operator is returned, which fails every operation.
+func New(t Type, ops *types.Options) Operator {
+	registryMu.RLock()
+	f, ok := registry[t]
+	registryMu.RUnlock()
+	if !ok {
+		return &Unknown{}
+	}
+	return f(ops)
+}
@@ -0,0 +1,38 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/hydroform/provision/internal/operator/mocks"
+	"github.com/kyma-project/hydroform/provision/internal/operator/native"
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+func TestRegistry(t *testing.T) {
+	require.IsType(t, &native.Operator{}, New(NativeOperator, &types.Options{}), "The native operator should be registered by default")
+
+	_, err := New("replay", &types.Options{}).Create(context.Background(), types.Gardener, nil)
+	require.EqualError(t, err, "unknown operator", "An unregistered type should result in the Unknown operator")
+
+	var received *types.Options
+	custom := &mocks.Operator{}
+	Register("replay", func(ops *types.Options) Operator {
+		received = ops
+		return custom
+	})
+	defer Unregister("replay")
+
+	ops := &types.Options{DataDir: "/tmp/hydroform"}
+	require.Same(t, custom, New("replay", ops))
+	require.Same(t, ops, received, "The factory should receive the options of the call")
+
+	replaced := &mocks.Operator{}
+	Register("replay", func(*types.Options) Operator { return replaced })
+	require.Same(t, replaced, New("replay", ops), "Registering a type again should replace its factory")
+
+	Unregister("replay")
+	require.IsType(t, &Unknown{}, New("replay", ops))
+}
@@ -0,0 +1,46 @@
+package operator
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+// Unknown represents an invalid operator.
+type Unknown struct {
+}
+
+// Create returns an error if the operator is unknown.
+func (u *Unknown) Create(ctx context.Context, p types.ProviderType, cfg map[string]interface{}) (*types.ClusterInfo, error) {
+	return nil, errors.New("unknown operator")
+}
+
+func (u *Unknown) Status(ctx context.Context, info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) (*types.ClusterStatus, error) {
+	return nil, errors.New("unknown operator")
+}
+
+// Credentials returns an error if the operator is unknown.
+func (u *Unknown) Credentials(ctx context.Context, info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) ([]byte, error) {
+	return nil, errors.New("unknown operator")
+}
+
+// Update returns an error if the operator is unknown.
+func (u *Unknown) Update(ctx context.Context, info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) (*types.ClusterInfo, error) {
+	return nil, errors.New("unknown operator")
+}
+
+// Hibernate returns an error if the operator is unknown.
+func (u *Unknown) Hibernate(ctx context.Context, info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) (*types.ClusterInfo, error) {
+	return nil, errors.New("unknown operator")
+}
+
+// WakeUp returns an error if the operator is unknown.
+func (u *Unknown) WakeUp(ctx context.Context, info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) (*types.ClusterInfo, error) {
+	return nil, errors.New("unknown operator")
+}
+
+// Delete returns an error if the operator is unknown.
+func (u *Unknown) Delete(ctx context.Context, info *types.ClusterInfo, p types.ProviderType, cfg map[string]interface{}) error {
+	return errors.New("unknown operator")
+}
@@ -0,0 +1,108 @@
+// Package provider holds the registry of the provider types Hydroform supports.
+//
+// The built-in providers register themselves. External packages can add new provider types with Register,
+// usually from an init function, and are then available through the functions of the provision package.
+package provider
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/kyma-project/hydroform/provision/operator"
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+// Provisioner runs the operations for one provider type.
+// The provision package takes care of the steps common to all providers, such as actions, state, and locking.
+type Provisioner interface {
+	// Provision creates the cluster and returns it enriched with the ClusterInfo.
+	Provision(ctx context.Context, cluster *types.Cluster, provider *types.Provider) (*types.Cluster, error)
+	// Status returns the current status of the cluster.
+	Status(ctx context.Context, cluster *types.Cluster, provider *types.Provider) (*types.ClusterStatus, error)
+	// Credentials returns the kubeconfig of the cluster.
+	Credentials(ctx context.Context, cluster *types.Cluster, provider *types.Provider) ([]byte, error)
+	// Update changes the cluster to match the given configuration and returns it enriched with the new ClusterInfo.
+	Update(ctx context.Context, cluster *types.Cluster, provider *types.Provider) (*types.Cluster, error)
+	// Deprovision removes the cluster.
+	Deprovision(ctx context.Context, cluster *types.Cluster, provider *types.Provider) error
+}
+
+// Hibernator is implemented by the provisioners of providers that support hibernation.
+type Hibernator interface {
+	// Hibernate scales down the cluster and returns it enriched with the new ClusterInfo.
+	Hibernate(ctx context.Context, cluster *types.Cluster, provider *types.Provider) (*types.Cluster, error)
+	// WakeUp resumes a hibernated cluster and returns it enriched with the new ClusterInfo.
+	WakeUp(ctx context.Context, cluster *types.Cluster, provider *types.Provider) (*types.Cluster, error)
+}
+
+// Planner is implemented by the provisioners that can render the resources of a cluster before creating them.
+type Planner interface {
+	// Plan validates the cluster and the provider and renders the resource that Provision would create, without contacting the provider.
+	Plan(cluster *types.Cluster, provider *types.Provider) (*types.Plan, error)
+	// Diff compares the planned resource with the live resource of the cluster.
+	Diff(ctx context.Context, cluster *types.Cluster, provider *types.Provider) (*types.PlanDiff, error)
+}
+
+// Importer is implemented by the provisioners that can adopt clusters created without Hydroform.
+type Importer interface {
+	// Import reads the cluster with the name of the given cluster from the provider and returns the cluster and the provider that describe it.
+	Import(ctx context.Context, cluster *types.Cluster, provider *types.Provider) (*types.Cluster, *types.Provider, error)
+	// ImportManifest does the same as Import for a manifest of the cluster, such as a Gardener Shoot in YAML or JSON, without contacting the provider.
+	ImportManifest(manifest []byte, provider *types.Provider) (*types.Cluster, *types.Provider, error)
+}
+
+// Lister is implemented by the provisioners that can list the clusters of a project.
+type Lister interface {
+	// List returns the clusters in the project of the provider that match the filter, with their ClusterInfo.
+	List(ctx context.Context, provider *types.Provider, filter types.ListFilter) ([]types.Cluster, error)
+}
+
+// Factory creates the provisioner of a provider for the operator type and the options of a Hydroform call.
+type Factory func(operatorType operator.Type, ops ...types.Option) Provisioner
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[types.ProviderType]Factory{}
+)
+
+// Register makes the provisioner created by the factory available for the given provider type.
+// Registering a type again replaces its factory.
+func Register(t types.ProviderType, f Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[t] = f
+}
+
+// Unregister removes the provisioner of the given provider type.
+func Unregister(t types.ProviderType) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	delete(registry, t)
+}
+
+// New creates the provisioner of the given provider type. It fails if no provisioner is registered for the type.
+func New(t types.ProviderType, operatorType operator.Type, ops ...types.Option) (Provisioner, error) {
+	registryMu.RLock()
+	f, ok := registry[t]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, errors.Errorf("unknown provider %s", t)
+	}
+	return f(operatorType, ops...), nil
+}
+
+// Types returns the registered provider types in alphabetical order.
+func Types() []types.ProviderType {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	res := make([]types.ProviderType, 0, len(registry))
+	for t := range registry {
+		res = append(res, t)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res
+}
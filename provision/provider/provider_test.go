@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/hydroform/provision/operator"
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+type fakeProvisioner struct {
+	Provisioner
+	operatorType operator.Type
+	ops          []types.Option
+}
+
+func TestRegistry(t *testing.T) {
+	const custom types.ProviderType = "openstack"
+
+	_, err := New(custom, operator.NativeOperator)
+	require.EqualError(t, err, "unknown provider openstack")
+
+	Register(custom, func(operatorType operator.Type, ops ...types.Option) Provisioner {
+		return &fakeProvisioner{operatorType: operatorType, ops: ops}
+	})
+	defer Unregister(custom)
+	require.Contains(t, Types(), custom)
+
+	p, err := New(custom, "replay", types.Persistent())
+	require.NoError(t, err)
+	require.Equal(t, operator.Type("replay"), p.(*fakeProvisioner).operatorType, "The factory should receive the operator type")
+	require.Len(t, p.(*fakeProvisioner).ops, 1, "The factory should receive the options of the call")
+
+	replaced := &fakeProvisioner{}
+	Register(custom, func(operator.Type, ...types.Option) Provisioner { return replaced })
+	p, err = New(custom, operator.NativeOperator)
+	require.NoError(t, err)
+	require.Same(t, replaced, p, "Registering a type again should replace its factory")
+
+	Unregister(custom)
+	_, err = New(custom, operator.NativeOperator)
+	require.Error(t, err)
+	require.NotContains(t, Types(), custom)
+}
@@ -1,165 +1,173 @@
 package provision
 
 import (
-	"errors"
+	"context"
 	"path/filepath"
-	"runtime"
 	"strings"
 
-	"github.com/kyma-project/hydroform/provision/action"
+	// the built-in providers register themselves in the provider registry
+	_ "github.com/kyma-project/hydroform/provision/internal/aws"
+	_ "github.com/kyma-project/hydroform/provision/internal/azure"
+	_ "github.com/kyma-project/hydroform/provision/internal/gardener"
+	_ "github.com/kyma-project/hydroform/provision/internal/gcp"
+	_ "github.com/kyma-project/hydroform/provision/internal/kind"
 
-	"github.com/kyma-project/hydroform/provision/internal/azure"
-	"github.com/kyma-project/hydroform/provision/internal/gardener"
-	"github.com/kyma-project/hydroform/provision/internal/kind"
-
-	"github.com/kyma-project/hydroform/provision/internal/gcp"
-	"github.com/kyma-project/hydroform/provision/internal/operator"
+	"github.com/kyma-project/hydroform/provision/operator"
 	"github.com/kyma-project/hydroform/provision/types"
 )
 
-// Currently the operator can not be changed at runtime, but the lib is designed so that it might be changed in the future
-const provisioningOperator = operator.NativeOperator
+// defaultOperator runs the operations unless another operator is selected with types.WithOperator.
+const defaultOperator = operator.NativeOperator
+
+// operatorType returns the operator selected for a call.
+func operatorType(o *types.Options) operator.Type {
+	if o.Operator != "" {
+		return o.Operator
+	}
+	return defaultOperator
+}
 
-// Provisioner is the Hydroform interface that groups Provision, Status, Credentials, and Deprovision functions used to create and manage a cluster.
+// Provisioner is the Hydroform interface that groups Provision, Status, Credentials, Update, and Deprovision functions used to create and manage a cluster.
 type Provisioner interface {
 	Provision(cluster *types.Cluster, provider *types.Provider) (*types.Cluster, error)
 	Status(cluster *types.Cluster, provider *types.Provider) (*types.ClusterStatus, error)
 	Credentials(cluster *types.Cluster, provider *types.Provider) ([]byte, error)
+	Update(cluster *types.Cluster, provider *types.Provider) (*types.Cluster, error)
 	Deprovision(cluster *types.Cluster, provider *types.Provider) error
 }
 
+// ContextProvisioner is the context-aware counterpart of Provisioner.
+// The given context is propagated down to the provider API calls, so cancelling it or reaching its deadline aborts the running operation.
+type ContextProvisioner interface {
+	ProvisionContext(ctx context.Context, cluster *types.Cluster, provider *types.Provider) (*types.Cluster, error)
+	StatusContext(ctx context.Context, cluster *types.Cluster, provider *types.Provider) (*types.ClusterStatus, error)
+	CredentialsContext(ctx context.Context, cluster *types.Cluster, provider *types.Provider) ([]byte, error)
+	UpdateContext(ctx context.Context, cluster *types.Cluster, provider *types.Provider) (*types.Cluster, error)
+	DeprovisionContext(ctx context.Context, cluster *types.Cluster, provider *types.Provider) error
+}
+
 // Provision creates a new cluster for a given provider based on specific cluster and provider parameters. It returns a cluster object enriched with information from the provider, such as the IP address or the connection endpoint. This object is necessary for the other operations, such as retrieving the cluster status or deprovisioning the cluster. If the cluster cannot be created, the function returns an error.
+// With the Persistent option, the returned cluster and the effective provider configuration are saved in the state store, so that the other operations can refer to the cluster by its name and the type and project of its provider.
+// Providers that report progress save the state as soon as they accepted the creation, so a cluster whose creation fails afterwards can still be deprovisioned.
 func Provision(cluster *types.Cluster, provider *types.Provider, ops ...types.Option) (*types.Cluster, error) {
-	var err error
-	var cl *types.Cluster
+	return ProvisionContext(context.Background(), cluster, provider, ops...)
+}
 
-	if err = action.Before(); err != nil {
-		return cl, err
-	}
+// ProvisionContext is the same as Provision, but the operation is bound to the given context.
+func ProvisionContext(ctx context.Context, cluster *types.Cluster, provider *types.Provider, ops ...types.Option) (*types.Cluster, error) {
+	return NewRegistry(ops...).ProvisionContext(ctx, cluster, provider)
+}
 
-	if runtime.GOOS == "windows" {
-		provider.CredentialsFilePath = updateWindowsPath(provider.CredentialsFilePath)
-	}
+// Status returns the cluster status for a given provider, or an error if providing the status is not possible. The possible status values are defined in the ClusterStatus type.
+// A cluster without ClusterInfo is looked up in the state store by its name and the type and project of the provider.
+// If the provider only sets its type and project, the stored provider is used.
+func Status(cluster *types.Cluster, provider *types.Provider, ops ...types.Option) (*types.ClusterStatus, error) {
+	return StatusContext(context.Background(), cluster, provider, ops...)
+}
 
-	switch provider.Type {
-	case types.GCP:
-		cl, err = gcp.New(provisioningOperator, ops...).Provision(cluster, provider)
-	case types.Gardener:
-		cl, err = gardener.New(provisioningOperator, ops...).Provision(cluster, provider)
-	case types.AWS:
-		err = errors.New("aws not supported yet")
-	case types.Azure:
-		cl, err = azure.New(provisioningOperator, ops...).Provision(cluster, provider)
-	case types.Kind:
-		cl, err = kind.New(provisioningOperator, ops...).Provision(cluster, provider)
-	default:
-		err = errors.New("unknown provider")
-	}
+// StatusContext is the same as Status, but the operation is bound to the given context.
+func StatusContext(ctx context.Context, cluster *types.Cluster, provider *types.Provider, ops ...types.Option) (*types.ClusterStatus, error) {
+	return NewRegistry(ops...).StatusContext(ctx, cluster, provider)
+}
 
-	if err != nil {
-		return cl, err
-	}
-	return cl, action.After()
+// Credentials returns the kubeconfig for a specific cluster as a byte array.
+// A cluster without ClusterInfo is looked up in the state store by its name and the type and project of the provider.
+func Credentials(cluster *types.Cluster, provider *types.Provider, ops ...types.Option) ([]byte, error) {
+	return CredentialsContext(context.Background(), cluster, provider, ops...)
 }
 
-// Status returns the cluster status for a given provider, or an error if providing the status is not possible. The possible status values are defined in the ClusterStatus type.
-func Status(cluster *types.Cluster, provider *types.Provider, ops ...types.Option) (*types.ClusterStatus, error) {
-	var err error
-	var cs *types.ClusterStatus
+// CredentialsContext is the same as Credentials, but the operation is bound to the given context.
+func CredentialsContext(ctx context.Context, cluster *types.Cluster, provider *types.Provider, ops ...types.Option) ([]byte, error) {
+	return NewRegistry(ops...).CredentialsContext(ctx, cluster, provider)
+}
 
-	if err = action.Before(); err != nil {
-		return cs, err
-	}
+// Update changes an existing cluster, such as its Kubernetes version or the size and machines of its workers, to match the given cluster and provider parameters. It returns the cluster enriched with the updated information from the provider. If the cluster cannot be changed, the function returns an error.
+// If the cluster has no ClusterInfo, it is taken from the state store. With the Persistent option, the stored state is updated.
+func Update(cluster *types.Cluster, provider *types.Provider, ops ...types.Option) (*types.Cluster, error) {
+	return UpdateContext(context.Background(), cluster, provider, ops...)
+}
 
-	if runtime.GOOS == "windows" {
-		provider.CredentialsFilePath = updateWindowsPath(provider.CredentialsFilePath)
-	}
+// UpdateContext is the same as Update, but the operation is bound to the given context.
+func UpdateContext(ctx context.Context, cluster *types.Cluster, provider *types.Provider, ops ...types.Option) (*types.Cluster, error) {
+	return NewRegistry(ops...).UpdateContext(ctx, cluster, provider)
+}
 
-	switch provider.Type {
-	case types.GCP:
-		cs, err = gcp.New(provisioningOperator, ops...).Status(cluster, provider)
-	case types.Gardener:
-		cs, err = gardener.New(provisioningOperator, ops...).Status(cluster, provider)
-	case types.AWS:
-		err = errors.New("aws not supported yet")
-	case types.Azure:
-		cs, err = azure.New(provisioningOperator, ops...).Status(cluster, provider)
-	case types.Kind:
-		cs, err = kind.New(provisioningOperator, ops...).Status(cluster, provider)
-	default:
-		err = errors.New("unknown provider")
-	}
+// Hibernate scales down an existing cluster to save costs while keeping its state. The cluster can be resumed with WakeUp. Hibernation is supported for providers whose provisioner implements provider.Hibernator, such as Gardener.
+func Hibernate(cluster *types.Cluster, provider *types.Provider, ops ...types.Option) (*types.Cluster, error) {
+	return HibernateContext(context.Background(), cluster, provider, ops...)
+}
 
-	if err != nil {
-		return cs, err
-	}
-	return cs, action.After()
+// HibernateContext is the same as Hibernate, but the operation is bound to the given context.
+func HibernateContext(ctx context.Context, cluster *types.Cluster, provider *types.Provider, ops ...types.Option) (*types.Cluster, error) {
+	return NewRegistry(ops...).HibernateContext(ctx, cluster, provider)
 }
 
-// Credentials returns the kubeconfig for a specific cluster as a byte array.
-func Credentials(cluster *types.Cluster, provider *types.Provider, ops ...types.Option) ([]byte, error) {
-	var err error
-	var cr []byte
+// WakeUp resumes a cluster hibernated with Hibernate. Hibernation is supported for providers whose provisioner implements provider.Hibernator, such as Gardener.
+func WakeUp(cluster *types.Cluster, provider *types.Provider, ops ...types.Option) (*types.Cluster, error) {
+	return WakeUpContext(context.Background(), cluster, provider, ops...)
+}
 
-	if err = action.Before(); err != nil {
-		return cr, err
-	}
+// WakeUpContext is the same as WakeUp, but the operation is bound to the given context.
+func WakeUpContext(ctx context.Context, cluster *types.Cluster, provider *types.Provider, ops ...types.Option) (*types.Cluster, error) {
+	return NewRegistry(ops...).WakeUpContext(ctx, cluster, provider)
+}
 
-	if runtime.GOOS == "windows" {
-		provider.CredentialsFilePath = updateWindowsPath(provider.CredentialsFilePath)
-	}
+// Plan validates the cluster and provider parameters and renders the resource that Provision would create, such as the Gardener Shoot, without contacting the provider.
+// The plan can be rendered as YAML or JSON to review it before creating the cluster. Planning is supported for providers whose provisioner implements provider.Planner, such as Gardener.
+func Plan(cluster *types.Cluster, provider *types.Provider, ops ...types.Option) (*types.Plan, error) {
+	return NewRegistry(ops...).Plan(cluster, provider)
+}
 
-	switch provider.Type {
-	case types.GCP:
-		cr, err = gcp.New(provisioningOperator, ops...).Credentials(cluster, provider)
-	case types.Gardener:
-		cr, err = gardener.New(provisioningOperator, ops...).Credentials(cluster, provider)
-	case types.AWS:
-		err = errors.New("aws not supported yet")
-	case types.Azure:
-		cr, err = azure.New(provisioningOperator, ops...).Credentials(cluster, provider)
-	case types.Kind:
-		cr, err = kind.New(provisioningOperator, ops...).Credentials(cluster, provider)
-	default:
-		err = errors.New("unknown provider")
-	}
+// Diff compares the resource that Provision would create with the live resource of the cluster. It shows whether the cluster exists, how the live cluster differs from the plan, and what Update would change.
+// Planning is supported for providers whose provisioner implements provider.Planner, such as Gardener.
+func Diff(cluster *types.Cluster, provider *types.Provider, ops ...types.Option) (*types.PlanDiff, error) {
+	return DiffContext(context.Background(), cluster, provider, ops...)
+}
 
-	if err != nil {
-		return cr, err
-	}
-	return cr, action.After()
+// DiffContext is the same as Diff, but the operation is bound to the given context.
+func DiffContext(ctx context.Context, cluster *types.Cluster, provider *types.Provider, ops ...types.Option) (*types.PlanDiff, error) {
+	return NewRegistry(ops...).DiffContext(ctx, cluster, provider)
 }
 
-// Deprovision removes an existing cluster along or returns an error if removing the cluster is not possible.
-func Deprovision(cluster *types.Cluster, provider *types.Provider, ops ...types.Option) error {
-	var err error
+// Import reads an existing cluster created without Hydroform, such as a Gardener Shoot created in the dashboard, by the name of the given cluster.
+// It returns the cluster and the provider with the configuration that describe it, so that the cluster can be managed with Status, Update, and Deprovision.
+// With the Persistent option, the imported cluster is saved in the state store. Import is supported for providers whose provisioner implements provider.Importer, such as Gardener.
+func Import(cluster *types.Cluster, provider *types.Provider, ops ...types.Option) (*types.Cluster, *types.Provider, error) {
+	return ImportContext(context.Background(), cluster, provider, ops...)
+}
 
-	if err = action.Before(); err != nil {
-		return err
-	}
+// ImportContext is the same as Import, but the operation is bound to the given context.
+func ImportContext(ctx context.Context, cluster *types.Cluster, provider *types.Provider, ops ...types.Option) (*types.Cluster, *types.Provider, error) {
+	return NewRegistry(ops...).ImportContext(ctx, cluster, provider)
+}
 
-	if runtime.GOOS == "windows" {
-		provider.CredentialsFilePath = updateWindowsPath(provider.CredentialsFilePath)
-	}
+// ImportManifest is the same as Import, but it reads the cluster from a manifest, such as a Gardener Shoot in YAML or JSON, instead of the provider.
+// The type and the credentials of the returned provider are taken from the given provider.
+func ImportManifest(manifest []byte, provider *types.Provider, ops ...types.Option) (*types.Cluster, *types.Provider, error) {
+	return NewRegistry(ops...).ImportManifest(manifest, provider)
+}
 
-	switch provider.Type {
-	case types.GCP:
-		err = gcp.New(provisioningOperator, ops...).Deprovision(cluster, provider)
-	case types.Gardener:
-		err = gardener.New(provisioningOperator, ops...).Deprovision(cluster, provider)
-	case types.AWS:
-		err = errors.New("aws not supported yet")
-	case types.Azure:
-		err = azure.New(provisioningOperator, ops...).Deprovision(cluster, provider)
-	case types.Kind:
-		err = kind.New(provisioningOperator, ops...).Deprovision(cluster, provider)
-	default:
-		err = errors.New("unknown provider")
-	}
-	if err != nil {
-		return err
-	}
-	return action.After()
+// List returns the clusters in the project of the provider that match the filter, with their status, version, region, and creation details in the ClusterInfo.
+// Listing is supported for providers whose provisioner implements provider.Lister, such as Gardener.
+func List(provider *types.Provider, filter types.ListFilter, ops ...types.Option) ([]types.Cluster, error) {
+	return ListContext(context.Background(), provider, filter, ops...)
+}
+
+// ListContext is the same as List, but the operation is bound to the given context.
+func ListContext(ctx context.Context, provider *types.Provider, filter types.ListFilter, ops ...types.Option) ([]types.Cluster, error) {
+	return NewRegistry(ops...).ListContext(ctx, provider, filter)
+}
+
+// Deprovision removes an existing cluster along or returns an error if removing the cluster is not possible.
+// A cluster without ClusterInfo is looked up in the state store by its name and the type and project of the provider. The stored state is removed together with the cluster.
+// Gardener clusters are deleted with the required confirmation, and Deprovision waits until the shoot is gone or the delete timeout expires.
+func Deprovision(cluster *types.Cluster, provider *types.Provider, ops ...types.Option) error {
+	return DeprovisionContext(context.Background(), cluster, provider, ops...)
+}
+
+// DeprovisionContext is the same as Deprovision, but the operation is bound to the given context.
+func DeprovisionContext(ctx context.Context, cluster *types.Cluster, provider *types.Provider, ops ...types.Option) error {
+	return NewRegistry(ops...).DeprovisionContext(ctx, cluster, provider)
 }
 
 func updateWindowsPath(windowsPath string) string {
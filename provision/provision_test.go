@@ -0,0 +1,34 @@
+package provision
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/hydroform/provision/internal/operator/mocks"
+	"github.com/kyma-project/hydroform/provision/operator"
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+func TestWithOperator(t *testing.T) {
+	t.Parallel()
+
+	custom := &mocks.Operator{}
+	status := &types.ClusterStatus{Phase: types.Provisioned}
+	custom.On("Status", mock.Anything, mock.Anything, types.Kind, mock.Anything).Return(status, nil)
+	operator.Register("test-custom", func(*types.Options) operator.Operator { return custom })
+	defer operator.Unregister("test-custom")
+
+	cluster := &types.Cluster{Name: "hydro", ClusterInfo: &types.ClusterInfo{}}
+	provider := &types.Provider{Type: types.Kind, ProjectName: "my-project"}
+
+	res, err := StatusContext(context.Background(), cluster, provider, types.WithOperator("test-custom"))
+	require.NoError(t, err)
+	require.Equal(t, status, res, "The status should come from the selected operator")
+	custom.AssertExpectations(t)
+
+	_, err = StatusContext(context.Background(), cluster, provider, types.WithOperator("missing"))
+	require.EqualError(t, err, "unknown operator")
+}
@@ -0,0 +1,395 @@
+package provision
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/kyma-project/hydroform/provision/action"
+	providers "github.com/kyma-project/hydroform/provision/provider"
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+var (
+	_ Provisioner        = &Registry{}
+	_ ContextProvisioner = &Registry{}
+)
+
+// Registry implements Provisioner and ContextProvisioner on top of the provisioners registered in the provider package.
+// It runs the steps shared by all providers, such as the hooks, the cluster state, and the lock, and dispatches the operation to the provisioner of the provider type.
+// New provider types become available in the Registry once they are registered with provider.Register.
+type Registry struct {
+	ops []types.Option
+}
+
+// NewRegistry creates a Registry that applies the given options to each operation.
+func NewRegistry(ops ...types.Option) *Registry {
+	return &Registry{ops: ops}
+}
+
+// operation describes the steps run around an operation of a provisioner.
+type operation struct {
+	// loadState completes a cluster without ClusterInfo from the state store.
+	loadState bool
+	// keepCluster takes only the ClusterInfo from the stored state.
+	keepCluster bool
+	// lock holds the cluster lock while the operation runs.
+	lock bool
+	// saveWhenStarted saves the state as soon as the provider accepted the creation of the cluster.
+	saveWhenStarted bool
+}
+
+// run prepares the cluster and the provider for an operation and calls fn with the provisioner of the provider type.
+// The hooks of the call run around it. The hooks run after the operation also get its result and error.
+func (r *Registry) run(ctx context.Context, name types.HookOperation, cluster *types.Cluster, provider *types.Provider, op operation,
+	fn func(ctx context.Context, o *types.Options, p providers.Provisioner, cluster *types.Cluster, provider *types.Provider) (interface{}, error)) (interface{}, error) {
+	if err := action.Before(); err != nil {
+		return nil, err
+	}
+
+	o := options(r.ops)
+	log := o.Log().With("operation", string(name), "cluster", clusterName(cluster), "provider", providerType(provider))
+	log.Info("operation started")
+	start := time.Now()
+
+	event := types.HookEvent{Operation: name, Cluster: cluster, Provider: provider}
+	event.Result, event.Err = r.call(ctx, o, &event, op, fn)
+
+	if event.Err != nil {
+		log.Error("operation failed", "duration", time.Since(start), "error", event.Err)
+	} else {
+		log.Info("operation finished", "duration", time.Since(start))
+	}
+
+	if err := runHooks(ctx, o.AfterHooks, event); err != nil {
+		if event.Err != nil {
+			return event.Result, errors.Join(event.Err, err)
+		}
+		return event.Result, err
+	}
+	if event.Err != nil {
+		return event.Result, event.Err
+	}
+	return event.Result, action.After()
+}
+
+// call locks the cluster, loads its state, and runs the before hooks and the operation. It updates the event with the cluster and the provider the operation runs with.
+// The lock is acquired first, so that the state and the hooks see the cluster as it is while the operation runs.
+func (r *Registry) call(ctx context.Context, o *types.Options, event *types.HookEvent, op operation,
+	fn func(ctx context.Context, o *types.Options, p providers.Provisioner, cluster *types.Cluster, provider *types.Provider) (interface{}, error)) (interface{}, error) {
+	cluster, provider := event.Cluster, event.Provider
+	if op.lock {
+		switch {
+		case cluster == nil:
+			return nil, errors.New("cluster cannot be empty")
+		case provider == nil:
+			return nil, errors.New("provider cannot be empty")
+		}
+		var unlock func()
+		var err error
+		if ctx, unlock, err = lockCluster(ctx, o, cluster, provider); err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
+
+	if op.loadState {
+		var err error
+		if cluster, provider, err = loadState(ctx, o, cluster, provider, op.keepCluster); err != nil {
+			return nil, err
+		}
+		event.Cluster, event.Provider = cluster, provider
+	}
+
+	for _, h := range o.BeforeHooks {
+		if err := h(ctx, *event); err != nil {
+			return nil, err
+		}
+	}
+
+	if runtime.GOOS == "windows" {
+		provider.CredentialsFilePath = updateWindowsPath(provider.CredentialsFilePath)
+	}
+
+	ops := r.ops
+	if op.saveWhenStarted && o.Persistent {
+		ops = append(ops[:len(ops):len(ops)], types.WithProgress(saveWhenStarted(ctx, o, cluster, provider)))
+	}
+	p, err := providers.New(provider.Type, operatorType(o), ops...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := fn(ctx, o, p, cluster, provider)
+	if cause := context.Cause(ctx); err != nil && errors.Is(cause, types.ErrLockLost) {
+		err = errors.Join(err, cause)
+	}
+	return res, err
+}
+
+// clusterName returns the name of the cluster for the logs, which is empty for a nil cluster.
+func clusterName(cluster *types.Cluster) string {
+	if cluster == nil {
+		return ""
+	}
+	return cluster.Name
+}
+
+// providerType returns the type of the provider for the logs, which is empty for a nil provider.
+func providerType(provider *types.Provider) string {
+	if provider == nil {
+		return ""
+	}
+	return string(provider.Type)
+}
+
+// runHooks runs all hooks with the event and returns their errors.
+func runHooks(ctx context.Context, hooks []types.Hook, event types.HookEvent) error {
+	var errs []error
+	for _, h := range hooks {
+		if err := h(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Provision calls ProvisionContext with a background context.
+func (r *Registry) Provision(cluster *types.Cluster, provider *types.Provider) (*types.Cluster, error) {
+	return r.ProvisionContext(context.Background(), cluster, provider)
+}
+
+// ProvisionContext creates the cluster with the provisioner of the provider type and saves its state if persistence is enabled.
+// The state is saved as soon as the provider reports that it accepted the creation, and again with the created cluster.
+func (r *Registry) ProvisionContext(ctx context.Context, cluster *types.Cluster, provider *types.Provider) (*types.Cluster, error) {
+	res, err := r.run(ctx, types.ProvisionHook, cluster, provider, operation{lock: true, saveWhenStarted: true},
+		func(ctx context.Context, o *types.Options, p providers.Provisioner, cluster *types.Cluster, provider *types.Provider) (interface{}, error) {
+			cl, err := p.Provision(ctx, cluster, provider)
+			if err != nil {
+				return cl, err
+			}
+			return cl, saveState(ctx, o, cl, provider)
+		})
+	cl, _ := res.(*types.Cluster)
+	return cl, err
+}
+
+// Status calls StatusContext with a background context.
+func (r *Registry) Status(cluster *types.Cluster, provider *types.Provider) (*types.ClusterStatus, error) {
+	return r.StatusContext(context.Background(), cluster, provider)
+}
+
+// StatusContext returns the cluster status reported by the provisioner of the provider type.
+func (r *Registry) StatusContext(ctx context.Context, cluster *types.Cluster, provider *types.Provider) (*types.ClusterStatus, error) {
+	res, err := r.run(ctx, types.StatusHook, cluster, provider, operation{loadState: true},
+		func(ctx context.Context, _ *types.Options, p providers.Provisioner, cluster *types.Cluster, provider *types.Provider) (interface{}, error) {
+			return p.Status(ctx, cluster, provider)
+		})
+	cs, _ := res.(*types.ClusterStatus)
+	return cs, err
+}
+
+// Credentials calls CredentialsContext with a background context.
+func (r *Registry) Credentials(cluster *types.Cluster, provider *types.Provider) ([]byte, error) {
+	return r.CredentialsContext(context.Background(), cluster, provider)
+}
+
+// CredentialsContext returns the kubeconfig provided by the provisioner of the provider type.
+func (r *Registry) CredentialsContext(ctx context.Context, cluster *types.Cluster, provider *types.Provider) ([]byte, error) {
+	res, err := r.run(ctx, types.CredentialsHook, cluster, provider, operation{loadState: true},
+		func(ctx context.Context, _ *types.Options, p providers.Provisioner, cluster *types.Cluster, provider *types.Provider) (interface{}, error) {
+			return p.Credentials(ctx, cluster, provider)
+		})
+	cr, _ := res.([]byte)
+	return cr, err
+}
+
+// Update calls UpdateContext with a background context.
+func (r *Registry) Update(cluster *types.Cluster, provider *types.Provider) (*types.Cluster, error) {
+	return r.UpdateContext(context.Background(), cluster, provider)
+}
+
+// UpdateContext changes the cluster with the provisioner of the provider type and updates its state if persistence is enabled.
+func (r *Registry) UpdateContext(ctx context.Context, cluster *types.Cluster, provider *types.Provider) (*types.Cluster, error) {
+	res, err := r.run(ctx, types.UpdateHook, cluster, provider, operation{loadState: true, keepCluster: true, lock: true},
+		func(ctx context.Context, o *types.Options, p providers.Provisioner, cluster *types.Cluster, provider *types.Provider) (interface{}, error) {
+			cl, err := p.Update(ctx, cluster, provider)
+			if err != nil {
+				return cl, err
+			}
+			return cl, saveState(ctx, o, cl, provider)
+		})
+	cl, _ := res.(*types.Cluster)
+	return cl, err
+}
+
+// Hibernate calls HibernateContext with a background context.
+func (r *Registry) Hibernate(cluster *types.Cluster, provider *types.Provider) (*types.Cluster, error) {
+	return r.HibernateContext(context.Background(), cluster, provider)
+}
+
+// HibernateContext hibernates the cluster if the provisioner of the provider type implements provider.Hibernator.
+func (r *Registry) HibernateContext(ctx context.Context, cluster *types.Cluster, provider *types.Provider) (*types.Cluster, error) {
+	return r.setHibernation(ctx, cluster, provider, true)
+}
+
+// WakeUp calls WakeUpContext with a background context.
+func (r *Registry) WakeUp(cluster *types.Cluster, provider *types.Provider) (*types.Cluster, error) {
+	return r.WakeUpContext(context.Background(), cluster, provider)
+}
+
+// WakeUpContext resumes the cluster if the provisioner of the provider type implements provider.Hibernator.
+func (r *Registry) WakeUpContext(ctx context.Context, cluster *types.Cluster, provider *types.Provider) (*types.Cluster, error) {
+	return r.setHibernation(ctx, cluster, provider, false)
+}
+
+func (r *Registry) setHibernation(ctx context.Context, cluster *types.Cluster, provider *types.Provider, hibernate bool) (*types.Cluster, error) {
+	name := types.WakeUpHook
+	if hibernate {
+		name = types.HibernateHook
+	}
+
+	res, err := r.run(ctx, name, cluster, provider, operation{loadState: true, lock: true},
+		func(ctx context.Context, o *types.Options, p providers.Provisioner, cluster *types.Cluster, provider *types.Provider) (interface{}, error) {
+			h, ok := p.(providers.Hibernator)
+			if !ok {
+				return nil, fmt.Errorf("hibernation is not supported for provider %s", provider.Type)
+			}
+
+			var cl *types.Cluster
+			var err error
+			if hibernate {
+				cl, err = h.Hibernate(ctx, cluster, provider)
+			} else {
+				cl, err = h.WakeUp(ctx, cluster, provider)
+			}
+			if err != nil {
+				return cl, err
+			}
+			return cl, saveState(ctx, o, cl, provider)
+		})
+	cl, _ := res.(*types.Cluster)
+	return cl, err
+}
+
+// Plan renders the resource of the cluster if the provisioner of the provider type implements provider.Planner.
+// It does not run the hooks, as it does not change the cluster.
+func (r *Registry) Plan(cluster *types.Cluster, provider *types.Provider) (*types.Plan, error) {
+	p, err := r.planner(provider)
+	if err != nil {
+		return nil, err
+	}
+	return p.Plan(cluster, provider)
+}
+
+// Diff calls DiffContext with a background context.
+func (r *Registry) Diff(cluster *types.Cluster, provider *types.Provider) (*types.PlanDiff, error) {
+	return r.DiffContext(context.Background(), cluster, provider)
+}
+
+// DiffContext compares the planned resource with the live one if the provisioner of the provider type implements provider.Planner.
+// It does not run the hooks, as it does not change the cluster.
+func (r *Registry) DiffContext(ctx context.Context, cluster *types.Cluster, provider *types.Provider) (*types.PlanDiff, error) {
+	p, err := r.planner(provider)
+	if err != nil {
+		return nil, err
+	}
+	if runtime.GOOS == "windows" {
+		provider.CredentialsFilePath = updateWindowsPath(provider.CredentialsFilePath)
+	}
+	return p.Diff(ctx, cluster, provider)
+}
+
+// planner returns the provisioner of the provider type if it supports planning.
+func (r *Registry) planner(provider *types.Provider) (providers.Planner, error) {
+	p, err := providers.New(provider.Type, operatorType(options(r.ops)), r.ops...)
+	if err != nil {
+		return nil, err
+	}
+	pl, ok := p.(providers.Planner)
+	if !ok {
+		return nil, fmt.Errorf("planning is not supported for provider %s", provider.Type)
+	}
+	return pl, nil
+}
+
+// Import calls ImportContext with a background context.
+func (r *Registry) Import(cluster *types.Cluster, provider *types.Provider) (*types.Cluster, *types.Provider, error) {
+	return r.ImportContext(context.Background(), cluster, provider)
+}
+
+// ImportContext reads an existing cluster if the provisioner of the provider type implements provider.Importer, and saves its state if persistence is enabled.
+func (r *Registry) ImportContext(ctx context.Context, cluster *types.Cluster, provider *types.Provider) (*types.Cluster, *types.Provider, error) {
+	var imported *types.Provider
+	res, err := r.run(ctx, types.ImportHook, cluster, provider, operation{lock: true},
+		func(ctx context.Context, o *types.Options, p providers.Provisioner, cluster *types.Cluster, provider *types.Provider) (interface{}, error) {
+			im, ok := p.(providers.Importer)
+			if !ok {
+				return nil, fmt.Errorf("import is not supported for provider %s", provider.Type)
+			}
+
+			cl, pr, err := im.Import(ctx, cluster, provider)
+			if err != nil {
+				return cl, err
+			}
+			imported = pr
+			return cl, saveState(ctx, o, cl, pr)
+		})
+	cl, _ := res.(*types.Cluster)
+	return cl, imported, err
+}
+
+// ImportManifest reads a manifest of a cluster if the provisioner of the provider type implements provider.Importer.
+// It does not run the hooks and does not save the state, as it does not contact the provider.
+func (r *Registry) ImportManifest(manifest []byte, provider *types.Provider) (*types.Cluster, *types.Provider, error) {
+	p, err := providers.New(provider.Type, operatorType(options(r.ops)), r.ops...)
+	if err != nil {
+		return nil, nil, err
+	}
+	im, ok := p.(providers.Importer)
+	if !ok {
+		return nil, nil, fmt.Errorf("import is not supported for provider %s", provider.Type)
+	}
+	return im.ImportManifest(manifest, provider)
+}
+
+// List calls ListContext with a background context.
+func (r *Registry) List(provider *types.Provider, filter types.ListFilter) ([]types.Cluster, error) {
+	return r.ListContext(context.Background(), provider, filter)
+}
+
+// ListContext lists the clusters of the project if the provisioner of the provider type implements provider.Lister.
+// It does not run the hooks, as it does not act on a single cluster.
+func (r *Registry) ListContext(ctx context.Context, provider *types.Provider, filter types.ListFilter) ([]types.Cluster, error) {
+	p, err := providers.New(provider.Type, operatorType(options(r.ops)), r.ops...)
+	if err != nil {
+		return nil, err
+	}
+	l, ok := p.(providers.Lister)
+	if !ok {
+		return nil, fmt.Errorf("listing is not supported for provider %s", provider.Type)
+	}
+	if runtime.GOOS == "windows" {
+		provider.CredentialsFilePath = updateWindowsPath(provider.CredentialsFilePath)
+	}
+	return l.List(ctx, provider, filter)
+}
+
+// Deprovision calls DeprovisionContext with a background context.
+func (r *Registry) Deprovision(cluster *types.Cluster, provider *types.Provider) error {
+	return r.DeprovisionContext(context.Background(), cluster, provider)
+}
+
+// DeprovisionContext removes the cluster with the provisioner of the provider type and deletes its stored state.
+func (r *Registry) DeprovisionContext(ctx context.Context, cluster *types.Cluster, provider *types.Provider) error {
+	_, err := r.run(ctx, types.DeprovisionHook, cluster, provider, operation{loadState: true, lock: true},
+		func(ctx context.Context, o *types.Options, p providers.Provisioner, cluster *types.Cluster, provider *types.Provider) (interface{}, error) {
+			if err := p.Deprovision(ctx, cluster, provider); err != nil {
+				return nil, err
+			}
+			return nil, deleteState(ctx, o, cluster, provider)
+		})
+	return err
+}
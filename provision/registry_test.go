@@ -0,0 +1,320 @@
+package provision
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/hydroform/provision/operator"
+	"github.com/kyma-project/hydroform/provision/provider"
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+// staticProvisioner is a provisioner of an external provider type.
+type staticProvisioner struct {
+	provider.Provisioner
+	provisioned []string
+}
+
+func (s *staticProvisioner) Provision(_ context.Context, cluster *types.Cluster, _ *types.Provider) (*types.Cluster, error) {
+	s.provisioned = append(s.provisioned, cluster.Name)
+	c := *cluster
+	c.ClusterInfo = &types.ClusterInfo{Endpoint: "https://" + cluster.Name}
+	return &c, nil
+}
+
+func (s *staticProvisioner) Status(context.Context, *types.Cluster, *types.Provider) (*types.ClusterStatus, error) {
+	return &types.ClusterStatus{Phase: types.Provisioned}, nil
+}
+
+func TestRegistryDispatch(t *testing.T) {
+	t.Parallel()
+
+	for _, pt := range []types.ProviderType{types.GCP, types.Azure, types.AWS, types.Kind, types.Gardener} {
+		require.Contains(t, provider.Types(), pt, "The built-in provider %s should be registered", pt)
+	}
+
+	const custom types.ProviderType = "test-static"
+	static := &staticProvisioner{}
+	provider.Register(custom, func(operator.Type, ...types.Option) provider.Provisioner { return static })
+	defer provider.Unregister(custom)
+
+	r := NewRegistry(types.WithDataDir(t.TempDir()), types.Persistent())
+	p := &types.Provider{Type: custom, ProjectName: "my-project"}
+
+	cl, err := r.ProvisionContext(context.Background(), &types.Cluster{Name: "hydro"}, p)
+	require.NoError(t, err)
+	require.Equal(t, "https://hydro", cl.ClusterInfo.Endpoint)
+	require.Equal(t, []string{"hydro"}, static.provisioned, "The call should be dispatched to the registered provisioner")
+
+	// the stored state completes a cluster referred to by name
+	status, err := r.Status(&types.Cluster{Name: "hydro"}, &types.Provider{Type: custom, ProjectName: "my-project"})
+	require.NoError(t, err)
+	require.Equal(t, types.Provisioned, status.Phase)
+
+	_, err = r.Hibernate(&types.Cluster{Name: "hydro"}, p)
+	require.EqualError(t, err, "hibernation is not supported for provider test-static")
+	_, err = r.Plan(&types.Cluster{Name: "hydro"}, p)
+	require.EqualError(t, err, "planning is not supported for provider test-static")
+	_, _, err = r.Import(&types.Cluster{Name: "hydro"}, p)
+	require.EqualError(t, err, "import is not supported for provider test-static")
+	_, err = r.List(p, types.ListFilter{})
+	require.EqualError(t, err, "listing is not supported for provider test-static")
+
+	_, err = r.Status(&types.Cluster{Name: "hydro", ClusterInfo: &types.ClusterInfo{}}, &types.Provider{Type: "missing"})
+	require.EqualError(t, err, "unknown provider missing")
+}
+
+// startedProvisioner is a provisioner that accepts the creation of a cluster, but fails while waiting for it.
+type startedProvisioner struct {
+	provider.Provisioner
+	ops *types.Options
+}
+
+func (s *startedProvisioner) Provision(_ context.Context, cluster *types.Cluster, _ *types.Provider) (*types.Cluster, error) {
+	s.ops.ReportProgress(types.ProgressEvent{Type: types.OperationStarted, Operation: types.CreateOperation, Cluster: cluster.Name})
+	return cluster, errors.New("timed out waiting for the cluster")
+}
+
+func TestRegistrySaveWhenStarted(t *testing.T) {
+	t.Parallel()
+
+	const custom types.ProviderType = "test-started"
+	provider.Register(custom, func(_ operator.Type, ops ...types.Option) provider.Provisioner {
+		return &startedProvisioner{ops: options(ops)}
+	})
+	defer provider.Unregister(custom)
+
+	var events []types.ProgressEvent
+	o := []types.Option{types.WithDataDir(t.TempDir()), types.Persistent(), types.WithProgress(func(e types.ProgressEvent) { events = append(events, e) })}
+	p := &types.Provider{Type: custom, ProjectName: "my-project"}
+
+	_, err := ProvisionContext(context.Background(), &types.Cluster{Name: "hydro"}, p, o...)
+	require.EqualError(t, err, "timed out waiting for the cluster")
+	require.Len(t, events, 1, "The progress events should still reach the configured function")
+
+	// the cluster was accepted, so its state is kept for the next operations
+	cl, _, err := loadState(context.Background(), options(o), &types.Cluster{Name: "hydro"}, p, false)
+	require.NoError(t, err)
+	require.Equal(t, types.Provisioning, cl.ClusterInfo.Status.Phase)
+
+	// the same name in another project has no state
+	cl, _, err = loadState(context.Background(), options(o), &types.Cluster{Name: "hydro"}, &types.Provider{Type: custom, ProjectName: "other"}, false)
+	require.NoError(t, err)
+	require.Nil(t, cl.ClusterInfo)
+}
+
+// lostLocker is a locker that loses every lock right after acquiring it.
+type lostLocker struct {
+	lost chan struct{}
+}
+
+func (l *lostLocker) Lock(context.Context, string) error {
+	close(l.lost)
+	return nil
+}
+
+func (l *lostLocker) Unlock(context.Context, string) error { return nil }
+
+func (l *lostLocker) Lost(string) <-chan struct{} { return l.lost }
+
+// waitingProvisioner is a provisioner whose operations run until they are cancelled.
+type waitingProvisioner struct {
+	provider.Provisioner
+}
+
+func (w *waitingProvisioner) Provision(ctx context.Context, cluster *types.Cluster, _ *types.Provider) (*types.Cluster, error) {
+	<-ctx.Done()
+	return cluster, ctx.Err()
+}
+
+func TestRegistryLockLost(t *testing.T) {
+	t.Parallel()
+
+	const custom types.ProviderType = "test-lock-lost"
+	provider.Register(custom, func(operator.Type, ...types.Option) provider.Provisioner { return &waitingProvisioner{} })
+	defer provider.Unregister(custom)
+
+	_, err := ProvisionContext(context.Background(), &types.Cluster{Name: "hydro"}, &types.Provider{Type: custom},
+		types.WithLocker(&lostLocker{lost: make(chan struct{})}))
+	require.ErrorIs(t, err, types.ErrLockLost, "The operation should be cancelled when its lock is lost")
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+// orderLocker records when locks are acquired and released.
+type orderLocker struct {
+	calls *[]string
+}
+
+func (l *orderLocker) Lock(_ context.Context, key string) error {
+	*l.calls = append(*l.calls, "lock "+key)
+	return nil
+}
+
+func (l *orderLocker) Unlock(_ context.Context, key string) error {
+	*l.calls = append(*l.calls, "unlock "+key)
+	return nil
+}
+
+// orderStore records when states are loaded.
+type orderStore struct {
+	types.StateStore
+	calls *[]string
+}
+
+func (s *orderStore) Load(_ context.Context, key string) (*types.State, error) {
+	*s.calls = append(*s.calls, "load "+key)
+	return nil, types.ErrStateNotFound
+}
+
+func TestRegistryLockFirst(t *testing.T) {
+	t.Parallel()
+
+	const custom types.ProviderType = "test-lock-first"
+	provider.Register(custom, func(operator.Type, ...types.Option) provider.Provisioner { return &staticProvisioner{} })
+	defer provider.Unregister(custom)
+
+	var calls []string
+	_, err := HibernateContext(context.Background(), &types.Cluster{Name: "hydro"}, &types.Provider{Type: custom, ProjectName: "my-project"},
+		types.WithLocker(&orderLocker{calls: &calls}), types.WithStateStore(&orderStore{calls: &calls}),
+		types.WithBefore(func(context.Context, types.HookEvent) error {
+			calls = append(calls, "hook")
+			return nil
+		}))
+	require.EqualError(t, err, "hibernation is not supported for provider test-lock-first")
+	require.Equal(t, []string{
+		"lock test-lock-first/my-project/hydro",
+		"load test-lock-first/my-project/hydro",
+		"hook",
+		"unlock test-lock-first/my-project/hydro",
+	}, calls, "The state should be loaded and the hooks should run while the cluster is locked")
+
+	err = DeprovisionContext(context.Background(), &types.Cluster{Name: "hydro"}, nil, types.WithLocker(&orderLocker{calls: &calls}))
+	require.EqualError(t, err, "provider cannot be empty")
+}
+
+func TestRegistryHooks(t *testing.T) {
+	t.Parallel()
+
+	const custom types.ProviderType = "test-hooks"
+	provider.Register(custom, func(operator.Type, ...types.Option) provider.Provisioner { return &staticProvisioner{} })
+	defer provider.Unregister(custom)
+
+	var events []types.HookEvent
+	record := func(_ context.Context, e types.HookEvent) error {
+		events = append(events, e)
+		return nil
+	}
+	cluster := &types.Cluster{Name: "hydro"}
+	p := &types.Provider{Type: custom}
+
+	cl, err := ProvisionContext(context.Background(), cluster, p,
+		types.WithDataDir(t.TempDir()), types.WithBefore(record), types.WithAfter(record))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, types.HookEvent{Operation: types.ProvisionHook, Cluster: cluster, Provider: p}, events[0])
+	require.Equal(t, types.HookEvent{Operation: types.ProvisionHook, Cluster: cluster, Provider: p, Result: cl}, events[1])
+
+	// the hooks after the operation also run if it fails
+	events = nil
+	_, err = HibernateContext(context.Background(), &types.Cluster{Name: "hydro", ClusterInfo: &types.ClusterInfo{}}, p,
+		types.WithDataDir(t.TempDir()), types.WithAfter(record))
+	require.Error(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, types.HibernateHook, events[0].Operation)
+	require.Equal(t, err, events[0].Err)
+
+	// a failing hook before the operation prevents it, and the error is passed to the hooks after it
+	events = nil
+	failed := errors.New("not allowed")
+	_, err = StatusContext(context.Background(), &types.Cluster{Name: "hydro", ClusterInfo: &types.ClusterInfo{}}, p,
+		types.WithBefore(func(context.Context, types.HookEvent) error { return failed }), types.WithAfter(record))
+	require.ErrorIs(t, err, failed)
+	require.Len(t, events, 1)
+	require.Nil(t, events[0].Result)
+	require.ErrorIs(t, events[0].Err, failed)
+
+	// a failing hook after the operation is reported together with the error of the operation
+	_, err = HibernateContext(context.Background(), &types.Cluster{Name: "hydro", ClusterInfo: &types.ClusterInfo{}}, p,
+		types.WithDataDir(t.TempDir()), types.WithAfter(func(context.Context, types.HookEvent) error { return failed }))
+	require.ErrorIs(t, err, failed)
+	require.ErrorContains(t, err, "hibernation is not supported")
+}
+
+func TestRegistryHooksConcurrent(t *testing.T) {
+	t.Parallel()
+
+	const custom types.ProviderType = "test-concurrent-hooks"
+	provider.Register(custom, func(operator.Type, ...types.Option) provider.Provisioner { return &staticProvisioner{} })
+	defer provider.Unregister(custom)
+
+	const calls = 20
+	seen := make([]string, calls)
+	var wg sync.WaitGroup
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			cluster := &types.Cluster{Name: fmt.Sprintf("hydro-%d", i), ClusterInfo: &types.ClusterInfo{}}
+			_, err := StatusContext(context.Background(), cluster, &types.Provider{Type: custom},
+				types.WithAfter(func(_ context.Context, e types.HookEvent) error {
+					seen[i] = e.Cluster.Name
+					return nil
+				}))
+			require.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, name := range seen {
+		require.Equal(t, fmt.Sprintf("hydro-%d", i), name, "Each call should run its own hooks")
+	}
+}
+
+func TestRegistryLogging(t *testing.T) {
+	t.Parallel()
+
+	const custom types.ProviderType = "test-logging"
+	provider.Register(custom, func(operator.Type, ...types.Option) provider.Provisioner { return &staticProvisioner{} })
+	defer provider.Unregister(custom)
+
+	var buf bytes.Buffer
+	log := slog.New(slog.NewJSONHandler(&buf, nil))
+	p := &types.Provider{Type: custom}
+
+	_, err := ProvisionContext(context.Background(), &types.Cluster{Name: "hydro"}, p,
+		types.WithDataDir(t.TempDir()), types.WithLogger(log))
+	require.NoError(t, err)
+	_, err = HibernateContext(context.Background(), &types.Cluster{Name: "hydro", ClusterInfo: &types.ClusterInfo{}}, p,
+		types.WithDataDir(t.TempDir()), types.WithLogger(log))
+	require.Error(t, err)
+
+	var records []map[string]interface{}
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var r map[string]interface{}
+		require.NoError(t, dec.Decode(&r))
+		records = append(records, r)
+	}
+	require.Len(t, records, 4)
+
+	for _, r := range records {
+		require.Equal(t, "hydro", r["cluster"])
+		require.Equal(t, string(custom), r["provider"])
+	}
+	require.Equal(t, "operation started", records[0]["msg"])
+	require.Equal(t, "provision", records[0]["operation"])
+	require.Equal(t, "operation finished", records[1]["msg"])
+	require.Equal(t, "INFO", records[1]["level"])
+	require.Equal(t, "operation failed", records[3]["msg"])
+	require.Equal(t, "ERROR", records[3]["level"])
+	require.Equal(t, "hibernate", records[3]["operation"])
+	require.Contains(t, records[3]["error"], "hibernation is not supported")
+}
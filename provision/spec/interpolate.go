@@ -0,0 +1,68 @@
+package spec
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// envPattern matches $$ and the references ${NAME} and ${NAME:-default}.
+var envPattern = regexp.MustCompile(`\$\$|\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// interpolate replaces the environment variable references in the string values of the parsed document.
+// Keys and comments are left as they are, and a value cannot change the structure of the document.
+// Unquoted values are typed after the substitution, so that a reference can set a number or a boolean. Quoted values stay strings.
+// Variables that are not set and have no default are reported together.
+func interpolate(doc *yamlv3.Node, lookupEnv func(string) (string, bool)) error {
+	var missing []string
+	interpolateNode(doc, lookupEnv, &missing)
+	if len(missing) > 0 {
+		return errors.Errorf("environment variables not set: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func interpolateNode(n *yamlv3.Node, lookupEnv func(string) (string, bool), missing *[]string) {
+	switch n.Kind {
+	case yamlv3.DocumentNode, yamlv3.SequenceNode:
+		for _, c := range n.Content {
+			interpolateNode(c, lookupEnv, missing)
+		}
+	case yamlv3.MappingNode:
+		// the content holds the keys and the values in turns
+		for i := 1; i < len(n.Content); i += 2 {
+			interpolateNode(n.Content[i], lookupEnv, missing)
+		}
+	case yamlv3.ScalarNode:
+		if n.ShortTag() != "!!str" || !envPattern.MatchString(n.Value) {
+			return
+		}
+		n.Value = interpolateString(n.Value, lookupEnv, missing)
+		if n.Style&(yamlv3.SingleQuotedStyle|yamlv3.DoubleQuotedStyle|yamlv3.LiteralStyle|yamlv3.FoldedStyle) == 0 {
+			// the tag of an unquoted value is resolved again from its new value
+			n.Tag = ""
+		}
+	}
+}
+
+// interpolateString replaces the references in s and adds the variables that are not set and have no default to missing.
+func interpolateString(s string, lookupEnv func(string) (string, bool), missing *[]string) string {
+	return envPattern.ReplaceAllStringFunc(s, func(match string) string {
+		groups := envPattern.FindStringSubmatch(match)
+		if groups[1] == "" {
+			// $$ escapes a literal $
+			return "$"
+		}
+
+		if v, ok := lookupEnv(groups[1]); ok {
+			return v
+		}
+		if strings.Contains(match, ":-") {
+			return groups[3]
+		}
+		*missing = append(*missing, groups[1])
+		return match
+	})
+}
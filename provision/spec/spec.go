@@ -0,0 +1,227 @@
+// Package spec loads declarative cluster specifications from YAML or JSON files.
+//
+// A spec file describes the cluster and the provider Hydroform works with:
+//
+//	apiVersion: hydroform.kyma-project.io/v1alpha1
+//	kind: ClusterSpec
+//	include:
+//	  - base.yaml
+//	cluster:
+//	  name: hydro-gcp-01
+//	  kubernetesVersion: "1.27"
+//	provider:
+//	  type: gardener
+//	  credentialsFilePath: ${GARDENER_KUBECONFIG}
+//	overlays:
+//	  production:
+//	    cluster:
+//	      nodeCount: 5
+//
+// Values can reference environment variables as ${NAME} or ${NAME:-default}. Use $$ for a literal $.
+// The references are replaced after the file is parsed, so a value cannot change the structure of the file, and comments are ignored.
+// Unquoted values are typed after the replacement, for example nodeCount: ${NODES} is a number. Quoted values stay strings.
+// In flow collections, such as [a, b], quote the references, as { starts a map there.
+// Included files are resolved relative to the including file and are applied first.
+// The overlay of the selected environment is applied last.
+// Unknown fields are reported as errors.
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	yamlv3 "gopkg.in/yaml.v3"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+const (
+	// APIVersion is the version of the spec file format.
+	APIVersion = "hydroform.kyma-project.io/v1alpha1"
+	// Kind is the kind of spec files.
+	Kind = "ClusterSpec"
+)
+
+// Spec is a declarative specification of a cluster and its provider.
+type Spec struct {
+	// APIVersion is the version of the spec file format. It has to be APIVersion.
+	APIVersion string `json:"apiVersion,omitempty"`
+	// Kind has to be Kind.
+	Kind string `json:"kind,omitempty"`
+	// Include lists the files this spec is based on, relative to the file.
+	// The files are applied in order, so later files and the spec itself override earlier ones.
+	Include []string `json:"include,omitempty"`
+	// Cluster specifies the cluster.
+	Cluster *types.Cluster `json:"cluster,omitempty"`
+	// Provider specifies the provider, including its custom configurations.
+	Provider *types.Provider `json:"provider,omitempty"`
+	// Overlays holds changes per environment that are applied on top of the spec.
+	Overlays map[string]Overlay `json:"overlays,omitempty"`
+}
+
+// Overlay holds the changes of a spec for one environment.
+// Maps are merged with the values of the spec, all other values replace them.
+type Overlay struct {
+	Cluster  *types.Cluster  `json:"cluster,omitempty"`
+	Provider *types.Provider `json:"provider,omitempty"`
+}
+
+// LoadOption is a function that allows to configure how spec files are loaded.
+type LoadOption func(*loadOptions)
+
+type loadOptions struct {
+	environment string
+	lookupEnv   func(string) (string, bool)
+}
+
+// WithEnvironment applies the overlay of the given environment.
+// Loading fails if no file defines an overlay for the environment.
+func WithEnvironment(name string) LoadOption {
+	return func(ops *loadOptions) {
+		ops.environment = name
+	}
+}
+
+// WithLookupEnv sets the function used to resolve environment variables. Defaults to os.LookupEnv.
+func WithLookupEnv(lookup func(string) (string, bool)) LoadOption {
+	return func(ops *loadOptions) {
+		ops.lookupEnv = lookup
+	}
+}
+
+// Load reads the spec file at the given path and returns the resolved spec.
+// The includes and the overlay of the selected environment are already applied, so Include and Overlays are empty.
+func Load(path string, ops ...LoadOption) (*Spec, error) {
+	options := &loadOptions{
+		lookupEnv: os.LookupEnv,
+	}
+	for _, o := range ops {
+		o(options)
+	}
+
+	doc, err := loadFile(path, options, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	overlays, _ := doc["overlays"].(map[string]interface{})
+	delete(doc, "overlays")
+	delete(doc, "include")
+	if options.environment != "" {
+		overlay, ok := overlays[options.environment].(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("no overlay defined for environment %s", options.environment)
+		}
+		doc = merge(doc, overlay)
+	}
+
+	s := &Spec{}
+	if err := decodeStrict(doc, s); err != nil {
+		return nil, errors.Wrapf(err, "invalid spec %s", path)
+	}
+	if s.APIVersion != APIVersion {
+		return nil, errors.Errorf("unsupported apiVersion %q in spec %s, expected %s", s.APIVersion, path, APIVersion)
+	}
+	if s.Kind != Kind {
+		return nil, errors.Errorf("unsupported kind %q in spec %s, expected %s", s.Kind, path, Kind)
+	}
+	return s, nil
+}
+
+// loadFile reads a spec file and merges it on top of its includes. Visited holds the files that are being loaded, to detect cycles.
+func loadFile(path string, ops *loadOptions, visited []string) (map[string]interface{}, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range visited {
+		if v == abs {
+			return nil, errors.Errorf("spec %s includes itself", path)
+		}
+	}
+	visited = append(visited, abs)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read spec")
+	}
+	// the references are replaced in the parsed values, so that they cannot change the structure of the file
+	node := &yamlv3.Node{}
+	if err := yamlv3.Unmarshal(data, node); err != nil {
+		return nil, errors.Wrapf(err, "unable to parse spec %s", path)
+	}
+	if err := interpolate(node, ops.lookupEnv); err != nil {
+		return nil, errors.Wrapf(err, "unable to interpolate spec %s", path)
+	}
+	if node.Kind != 0 {
+		if data, err = yamlv3.Marshal(node); err != nil {
+			return nil, errors.Wrapf(err, "unable to interpolate spec %s", path)
+		}
+	}
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse spec %s", path)
+	}
+
+	// each file is checked on its own, so unknown fields are reported with the file they are in
+	s := &Spec{}
+	if err := decodeStrictJSON(data, s); err != nil {
+		return nil, errors.Wrapf(err, "invalid spec %s", path)
+	}
+
+	doc := map[string]interface{}{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, errors.Wrapf(err, "unable to parse spec %s", path)
+	}
+
+	base := map[string]interface{}{}
+	for _, inc := range s.Include {
+		if !filepath.IsAbs(inc) {
+			inc = filepath.Join(filepath.Dir(path), inc)
+		}
+		included, err := loadFile(inc, ops, visited)
+		if err != nil {
+			return nil, err
+		}
+		base = merge(base, included)
+	}
+	return merge(base, doc), nil
+}
+
+// merge returns base with the values of override applied. Maps are merged recursively, all other values are replaced.
+func merge(base, override map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(base))
+	for k, v := range base {
+		res[k] = v
+	}
+	for k, v := range override {
+		baseMap, baseOk := res[k].(map[string]interface{})
+		overrideMap, overrideOk := v.(map[string]interface{})
+		if baseOk && overrideOk {
+			res[k] = merge(baseMap, overrideMap)
+			continue
+		}
+		res[k] = v
+	}
+	return res
+}
+
+func decodeStrict(doc map[string]interface{}, out interface{}) error {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return decodeStrictJSON(data, out)
+}
+
+func decodeStrictJSON(data []byte, out interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(out)
+}
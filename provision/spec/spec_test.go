@@ -0,0 +1,259 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	yamlv3 "gopkg.in/yaml.v3"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+const baseSpec = `apiVersion: hydroform.kyma-project.io/v1alpha1
+kind: ClusterSpec
+cluster:
+  name: hydro-gcp-01
+  kubernetesVersion: "1.27"
+  diskSizeGB: 30
+  nodeCount: 2
+  location: europe-west4
+  machineType: n1-standard-4
+provider:
+  type: gardener
+  projectName: ${PROJECT}
+  credentialsFilePath: ${KUBECONFIG_PATH:-/etc/gardener/kubeconfig}
+  customConfigurations:
+    target_provider: gcp
+    target_secret: gcp-secret
+    zones: [europe-west4-b]
+    worker_minimum: 2
+overlays:
+  production:
+    cluster:
+      nodeCount: 5
+    provider:
+      customConfigurations:
+        worker_minimum: 3
+`
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+	return dir
+}
+
+func lookup(env map[string]string) LoadOption {
+	return WithLookupEnv(func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	})
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+	dir := writeFiles(t, map[string]string{"cluster.yaml": baseSpec})
+
+	s, err := Load(filepath.Join(dir, "cluster.yaml"), lookup(map[string]string{"PROJECT": "my-project"}))
+	require.NoError(t, err)
+
+	require.Equal(t, "hydro-gcp-01", s.Cluster.Name)
+	require.Equal(t, 2, s.Cluster.NodeCount)
+	require.Equal(t, types.Gardener, s.Provider.Type)
+	require.Equal(t, "my-project", s.Provider.ProjectName)
+	require.Equal(t, "/etc/gardener/kubeconfig", s.Provider.CredentialsFilePath, "The default should be used for unset variables")
+	require.Nil(t, s.Overlays, "Overlays should be resolved")
+
+	// the custom configurations are converted to the types Hydroform expects
+	custom, err := s.Provider.Configurations()
+	require.NoError(t, err)
+	require.Equal(t, []string{"europe-west4-b"}, custom["zones"])
+	require.Equal(t, 2, custom["worker_minimum"])
+}
+
+func TestLoadInterpolation(t *testing.T) {
+	t.Parallel()
+	dir := writeFiles(t, map[string]string{"cluster.yaml": "# the project is set by ${CI_PIPELINE}\n" + baseSpec})
+
+	s, err := Load(filepath.Join(dir, "cluster.yaml"), lookup(map[string]string{"PROJECT": "my: project # 1\n\"two\""}))
+	require.NoError(t, err, "References in comments should be ignored")
+	require.Equal(t, "my: project # 1\n\"two\"", s.Provider.ProjectName, "A value should not change the structure of the spec")
+	require.Equal(t, "hydro-gcp-01", s.Cluster.Name)
+}
+
+func TestLoadOverlaysAndIncludes(t *testing.T) {
+	t.Parallel()
+	dir := writeFiles(t, map[string]string{
+		"base.yaml": baseSpec,
+		"prod.json": `{
+			"apiVersion": "hydroform.kyma-project.io/v1alpha1",
+			"kind": "ClusterSpec",
+			"include": ["base.yaml"],
+			"cluster": {"name": "hydro-prod"},
+			"overlays": {"production": {"cluster": {"machineType": "n1-standard-8"}}}
+		}`,
+	})
+	env := lookup(map[string]string{"PROJECT": "my-project"})
+
+	s, err := Load(filepath.Join(dir, "prod.json"), env, WithEnvironment("production"))
+	require.NoError(t, err)
+
+	require.Equal(t, "hydro-prod", s.Cluster.Name, "The including file should override its includes")
+	require.Equal(t, "1.27", s.Cluster.KubernetesVersion, "Values of the includes should be kept")
+	require.Equal(t, 5, s.Cluster.NodeCount, "The overlay of the include should be applied")
+	require.Equal(t, "n1-standard-8", s.Cluster.MachineType, "The overlay of the including file should be applied")
+	require.Equal(t, "gcp-secret", s.Provider.CustomConfigurations["target_secret"], "Maps should be merged")
+	require.EqualValues(t, 3, s.Provider.CustomConfigurations["worker_minimum"])
+
+	_, err = Load(filepath.Join(dir, "prod.json"), env, WithEnvironment("staging"))
+	require.Error(t, err, "Loading should fail for an unknown environment")
+}
+
+func TestLoadErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		files   map[string]string
+		env     map[string]string
+		message string
+	}{
+		{
+			name:    "Missing environment variable",
+			files:   map[string]string{"cluster.yaml": baseSpec},
+			message: "environment variables not set: PROJECT",
+		},
+		{
+			name: "Unknown field",
+			files: map[string]string{"cluster.yaml": `apiVersion: hydroform.kyma-project.io/v1alpha1
+kind: ClusterSpec
+cluster:
+  nodeCont: 3
+`},
+			message: `unknown field "nodeCont"`,
+		},
+		{
+			name: "Unknown field in an overlay",
+			files: map[string]string{"cluster.yaml": `apiVersion: hydroform.kyma-project.io/v1alpha1
+kind: ClusterSpec
+overlays:
+  dev:
+    provider:
+      projectNam: dev
+`},
+			message: `unknown field "projectNam"`,
+		},
+		{
+			name: "Unknown field in an include",
+			files: map[string]string{
+				"cluster.yaml": "apiVersion: hydroform.kyma-project.io/v1alpha1\nkind: ClusterSpec\ninclude: [base.yaml]\n",
+				"base.yaml":    "clusters: {}\n",
+			},
+			message: "base.yaml",
+		},
+		{
+			name: "Include cycle",
+			files: map[string]string{
+				"cluster.yaml": "apiVersion: hydroform.kyma-project.io/v1alpha1\nkind: ClusterSpec\ninclude: [base.yaml]\n",
+				"base.yaml":    "include: [cluster.yaml]\n",
+			},
+			message: "includes itself",
+		},
+		{
+			name:    "Unsupported version",
+			files:   map[string]string{"cluster.yaml": "apiVersion: v2\nkind: ClusterSpec\n"},
+			message: "unsupported apiVersion",
+		},
+		{
+			name:    "Missing kind",
+			files:   map[string]string{"cluster.yaml": "apiVersion: hydroform.kyma-project.io/v1alpha1\n"},
+			message: "unsupported kind",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			dir := writeFiles(t, tc.files)
+			_, err := Load(filepath.Join(dir, "cluster.yaml"), lookup(tc.env))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.message)
+		})
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	t.Parallel()
+	env := func(name string) (string, bool) {
+		switch name {
+		case "NAME":
+			return "hydro", true
+		case "NODES":
+			return "3", true
+		case "TRICKY":
+			return "a: b # not a comment\n- 'quoted'", true
+		}
+		return "", false
+	}
+
+	tests := []struct {
+		name     string
+		yaml     string
+		expected map[string]interface{}
+		message  string
+	}{
+		{
+			name:     "References, defaults and escapes",
+			yaml:     "name: ${NAME}-${SUFFIX:-01}\nprice: $$5\nempty: '${EMPTY:-}'\n",
+			expected: map[string]interface{}{"name": "hydro-01", "price": "$5", "empty": ""},
+		},
+		{
+			name:     "Unquoted values are typed",
+			yaml:     "nodes: ${NODES}\nquoted: \"${NODES}\"\n",
+			expected: map[string]interface{}{"nodes": float64(3), "quoted": "3"},
+		},
+		{
+			name:     "Values cannot change the structure",
+			yaml:     "value: ${TRICKY}\nlist:\n- ${TRICKY}\n- [\"${TRICKY}\"]\n",
+			expected: map[string]interface{}{"value": "a: b # not a comment\n- 'quoted'", "list": []interface{}{"a: b # not a comment\n- 'quoted'", []interface{}{"a: b # not a comment\n- 'quoted'"}}},
+		},
+		{
+			name:     "Keys and comments are left as they are",
+			yaml:     "# uses ${UNSET}\n${KEY}: value # or ${OTHER}\n",
+			expected: map[string]interface{}{"${KEY}": "value"},
+		},
+		{
+			name:    "Missing variables",
+			yaml:    "a: ${A}\nb: ${B:-b}\nc: [\"${C}\"]\n",
+			message: "environment variables not set: A, C",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			node := &yamlv3.Node{}
+			require.NoError(t, yamlv3.Unmarshal([]byte(tc.yaml), node))
+
+			err := interpolate(node, env)
+			if tc.message != "" {
+				require.EqualError(t, err, tc.message)
+				return
+			}
+			require.NoError(t, err)
+
+			// the interpolated document is written and read again, as Load does
+			data, err := yamlv3.Marshal(node)
+			require.NoError(t, err)
+			res := map[string]interface{}{}
+			require.NoError(t, yaml.Unmarshal(data, &res))
+			require.Equal(t, tc.expected, res)
+		})
+	}
+}
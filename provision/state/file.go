@@ -0,0 +1,118 @@
+// Package state provides the stores Hydroform can keep the state of clusters in.
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+// FileStore keeps the state of each cluster in a JSON file in a directory.
+type FileStore struct {
+	dir string
+}
+
+var _ types.StateStore = &FileStore{}
+
+// NewFileStore creates a store that keeps the state files in the given directory. The directory is created when the first state is saved.
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+// Save writes the state of the cluster to <dir>/<escaped key>.state.json, for example gardener%2Fmy-project%2Fhydro.state.json.
+// The file is replaced atomically, so a failed write keeps the previous state.
+func (f *FileStore) Save(ctx context.Context, key string, state *types.State) error {
+	path, err := f.file(key)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "unable to encode the cluster state")
+	}
+
+	if err := os.MkdirAll(f.dir, 0700); err != nil {
+		return errors.Wrap(err, "unable to create the state directory")
+	}
+	tmp, err := os.CreateTemp(f.dir, filepath.Base(path)+"-*")
+	if err != nil {
+		return errors.Wrap(err, "unable to write the cluster state")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "unable to write the cluster state")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "unable to write the cluster state")
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), path), "unable to write the cluster state")
+}
+
+// Load reads the state of the cluster from its file.
+func (f *FileStore) Load(ctx context.Context, key string) (*types.State, error) {
+	path, err := f.file(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, types.ErrStateNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read the cluster state")
+	}
+
+	state := &types.State{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, errors.Wrapf(err, "unable to decode the cluster state in %s", path)
+	}
+	return state, nil
+}
+
+// Delete removes the state file of the cluster.
+func (f *FileStore) Delete(ctx context.Context, key string) error {
+	path, err := f.file(key)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "unable to delete the cluster state")
+	}
+	return nil
+}
+
+// file returns the path of the state file. The key is escaped, so its separators cannot point outside the directory.
+func (f *FileStore) file(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(f.dir, url.PathEscape(key)+".state.json"), nil
+}
+
+// validateKey makes sure the key identifies a cluster.
+func validateKey(key string) error {
+	if key == "" {
+		return errors.New("the state store needs a cluster key")
+	}
+	return nil
+}
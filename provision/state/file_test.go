@@ -0,0 +1,83 @@
+package state
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+// testStore runs the checks every StateStore has to pass.
+func testStore(t *testing.T, store types.StateStore) {
+	ctx := context.Background()
+	key := "gardener/my-project/hydro"
+
+	_, err := store.Load(ctx, key)
+	require.ErrorIs(t, err, types.ErrStateNotFound, "Loading a missing state should fail with ErrStateNotFound")
+
+	state := &types.State{
+		Cluster: &types.Cluster{
+			Name:      "hydro",
+			NodeCount: 3,
+			ClusterInfo: &types.ClusterInfo{
+				Endpoint: "https://api.hydro.example.com",
+				Status:   &types.ClusterStatus{Phase: types.Provisioned},
+			},
+		},
+		Provider: &types.Provider{
+			Type:                 types.Gardener,
+			ProjectName:          "my-project",
+			CustomConfigurations: map[string]interface{}{"target_provider": "gcp"},
+		},
+	}
+	require.NoError(t, store.Save(ctx, key, state))
+
+	loaded, err := store.Load(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, state, loaded)
+
+	// saving again replaces the state
+	state.Cluster.NodeCount = 5
+	require.NoError(t, store.Save(ctx, key, state))
+	loaded, err = store.Load(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, 5, loaded.Cluster.NodeCount)
+
+	require.NoError(t, store.Delete(ctx, key))
+	_, err = store.Load(ctx, key)
+	require.ErrorIs(t, err, types.ErrStateNotFound, "The state should be gone after Delete")
+	require.NoError(t, store.Delete(ctx, key), "Deleting a missing state should not fail")
+
+	// the same cluster name in another project is a different cluster
+	require.NoError(t, store.Save(ctx, key, state))
+	_, err = store.Load(ctx, "gardener/other-project/hydro")
+	require.ErrorIs(t, err, types.ErrStateNotFound, "The state should be kept per provider and project")
+	require.NoError(t, store.Delete(ctx, key))
+
+	require.Error(t, store.Save(ctx, "", state), "An empty key should be rejected")
+}
+
+func TestFileStore(t *testing.T) {
+	t.Parallel()
+	dir := filepath.Join(t.TempDir(), "state")
+	store := NewFileStore(dir)
+
+	testStore(t, store)
+
+	require.NoError(t, store.Save(context.Background(), "gardener/my-project/hydro", &types.State{}))
+	info, err := os.Stat(filepath.Join(dir, "gardener%2Fmy-project%2Fhydro.state.json"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm(), "The state should only be readable by the user")
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "No temporary files should be left behind")
+
+	require.NoError(t, store.Save(context.Background(), "../hydro", &types.State{}))
+	_, err = os.Stat(filepath.Join(dir, "..%2Fhydro.state.json"))
+	require.NoError(t, err, "Path separators in the key should not leave the directory")
+}
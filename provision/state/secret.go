@@ -0,0 +1,131 @@
+package state
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+const (
+	secretPrefix = "hydroform-state-"
+	secretKey    = "state"
+	// managedByLabel marks the Secrets written by the SecretStore.
+	managedByLabel = "app.kubernetes.io/managed-by"
+	managedBy      = "hydroform"
+	// keyAnnotation is the annotation holding the state key, as the Secret name only contains a sanitized form of it.
+	keyAnnotation = "hydroform.kyma-project.io/state-key"
+)
+
+var invalidSecretChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// SecretStore keeps the state of each cluster in a Kubernetes Secret, for example in the cluster that runs a pipeline.
+type SecretStore struct {
+	client    kubernetes.Interface
+	namespace string
+}
+
+var _ types.StateStore = &SecretStore{}
+
+// NewSecretStore creates a store that keeps the states in Secrets named hydroform-state-<sanitized key>-<hash> in the given namespace.
+func NewSecretStore(client kubernetes.Interface, namespace string) *SecretStore {
+	return &SecretStore{
+		client:    client,
+		namespace: namespace,
+	}
+}
+
+// Save creates or updates the Secret of the cluster.
+func (s *SecretStore) Save(ctx context.Context, key string, state *types.State) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	name := secretName(key)
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return errors.Wrap(err, "unable to encode the cluster state")
+	}
+
+	secrets := s.client.CoreV1().Secrets(s.namespace)
+	secret, err := secrets.Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case k8sErrors.IsNotFound(err):
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   s.namespace,
+				Labels:      map[string]string{managedByLabel: managedBy},
+				Annotations: map[string]string{keyAnnotation: key},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{secretKey: data},
+		}
+		_, err = secrets.Create(ctx, secret, metav1.CreateOptions{})
+	case err == nil:
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[secretKey] = data
+		_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+	}
+	return errors.Wrap(err, "unable to save the cluster state")
+}
+
+// Load reads the state of the cluster from its Secret.
+func (s *SecretStore) Load(ctx context.Context, key string) (*types.State, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	secret, err := s.client.CoreV1().Secrets(s.namespace).Get(ctx, secretName(key), metav1.GetOptions{})
+	if k8sErrors.IsNotFound(err) {
+		return nil, types.ErrStateNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read the cluster state")
+	}
+
+	data, ok := secret.Data[secretKey]
+	if !ok {
+		return nil, errors.Errorf("secret %s/%s has no %s key", s.namespace, secret.Name, secretKey)
+	}
+	state := &types.State{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, errors.Wrapf(err, "unable to decode the cluster state in secret %s/%s", s.namespace, secret.Name)
+	}
+	return state, nil
+}
+
+// Delete removes the Secret of the cluster.
+func (s *SecretStore) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	err := s.client.CoreV1().Secrets(s.namespace).Delete(ctx, secretName(key), metav1.DeleteOptions{})
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return errors.Wrap(err, "unable to delete the cluster state")
+	}
+	return nil
+}
+
+// secretName turns the key into a valid object name. The hash keeps keys that only differ in invalid characters apart.
+func secretName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	name := strings.Trim(invalidSecretChars.ReplaceAllString(strings.ToLower(key), "-"), "-")
+	if len(name) > 40 {
+		name = name[:40]
+	}
+	return secretPrefix + name + "-" + hex.EncodeToString(sum[:])[:10]
+}
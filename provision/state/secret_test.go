@@ -0,0 +1,31 @@
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sFake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+func TestSecretStore(t *testing.T) {
+	t.Parallel()
+	client := k8sFake.NewSimpleClientset()
+	store := NewSecretStore(client, "pipelines")
+
+	testStore(t, store)
+
+	require.NoError(t, store.Save(context.Background(), "gardener/my-project/hydro", &types.State{}))
+	secrets, err := client.CoreV1().Secrets("pipelines").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, secrets.Items, 1)
+	secret := secrets.Items[0]
+	require.Regexp(t, `^hydroform-state-gardener-my-project-hydro-[0-9a-f]{10}$`, secret.Name)
+	require.Equal(t, "gardener/my-project/hydro", secret.Annotations["hydroform.kyma-project.io/state-key"])
+	require.Equal(t, "hydroform", secret.Labels["app.kubernetes.io/managed-by"])
+	require.Contains(t, secret.Data, "state")
+	require.NotEqual(t, secretName("gardener/my-project/hydro"), secretName("gardener/my_project/hydro"), "Keys that only differ in invalid characters should not share a Secret")
+}
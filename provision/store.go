@@ -0,0 +1,125 @@
+package provision
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kyma-project/hydroform/provision/state"
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+// options builds the Hydroform options from the given option functions.
+func options(ops []types.Option) *types.Options {
+	o := &types.Options{}
+	for _, op := range ops {
+		op(o)
+	}
+	return o
+}
+
+// stateStore returns the configured state store or, by default, a file store in the data directory.
+func stateStore(o *types.Options) types.StateStore {
+	if o.StateStore != nil {
+		return o.StateStore
+	}
+	return state.NewFileStore(o.Dir())
+}
+
+// clusterKey identifies a cluster in the state store and in the locker.
+// Clusters of different providers or projects can have the same name, so the key holds the provider type and the project name as well.
+func clusterKey(cluster *types.Cluster, provider *types.Provider) string {
+	return fmt.Sprintf("%s/%s/%s", provider.Type, provider.ProjectName, cluster.Name)
+}
+
+// loadState completes a cluster that has no ClusterInfo from the state store, so that a cluster can be referred to by its name and its provider.
+// If keepCluster is set, only the ClusterInfo is taken from the stored state, otherwise the stored cluster replaces the given one.
+// A provider that only sets the type and the project name is replaced by the stored provider.
+func loadState(ctx context.Context, o *types.Options, cluster *types.Cluster, provider *types.Provider, keepCluster bool) (*types.Cluster, *types.Provider, error) {
+	if cluster == nil {
+		return nil, nil, errors.New("cluster cannot be empty")
+	}
+	if provider == nil {
+		return cluster, nil, fmt.Errorf("provider cannot be empty, the state of cluster %s is stored per provider type and project", cluster.Name)
+	}
+
+	if cluster.ClusterInfo == nil {
+		s, err := stateStore(o).Load(ctx, clusterKey(cluster, provider))
+		switch {
+		case errors.Is(err, types.ErrStateNotFound):
+		case err != nil:
+			return cluster, provider, fmt.Errorf("unable to load the state of cluster %s: %w", cluster.Name, err)
+		default:
+			if s.Provider == nil || s.Provider.Type != provider.Type || s.Provider.ProjectName != provider.ProjectName {
+				return cluster, provider, fmt.Errorf("the stored state of cluster %s does not belong to provider %s in project %q", cluster.Name, provider.Type, provider.ProjectName)
+			}
+			if keepCluster {
+				c := *cluster
+				c.ClusterInfo = s.Cluster.ClusterInfo
+				cluster = &c
+			} else {
+				cluster = s.Cluster
+			}
+			if identifiesOnly(provider) {
+				provider = s.Provider
+			}
+		}
+	}
+	return cluster, provider, nil
+}
+
+// identifiesOnly checks if the provider only sets what is needed to find the state of a cluster.
+func identifiesOnly(provider *types.Provider) bool {
+	return provider.CredentialsFilePath == "" && len(provider.CustomConfigurations) == 0 &&
+		provider.Gardener == nil && provider.AWS == nil && provider.Kind == nil && provider.GCP == nil && provider.Azure == nil
+}
+
+// saveState stores the cluster and the effective provider configuration if persistence is enabled.
+func saveState(ctx context.Context, o *types.Options, cluster *types.Cluster, provider *types.Provider) error {
+	if !o.Persistent || cluster == nil {
+		return nil
+	}
+
+	// the typed configurations are merged into CustomConfigurations
+	p := *provider
+	if custom, err := provider.Configurations(); err == nil {
+		p.CustomConfigurations = custom
+		p.Gardener, p.AWS, p.Kind, p.GCP, p.Azure = nil, nil, nil, nil, nil
+	}
+
+	if err := stateStore(o).Save(ctx, clusterKey(cluster, provider), &types.State{Cluster: cluster, Provider: &p}); err != nil {
+		return fmt.Errorf("unable to save the state of cluster %s: %w", cluster.Name, err)
+	}
+	return nil
+}
+
+// saveWhenStarted returns a ProgressFunc that saves the state as soon as the provider reports that it accepted the creation of the cluster,
+// so that a cluster whose creation fails afterwards can still be found, for example to deprovision it.
+// The events are passed on to the ProgressFunc of the options.
+func saveWhenStarted(ctx context.Context, o *types.Options, cluster *types.Cluster, provider *types.Provider) types.ProgressFunc {
+	next := o.Progress
+	return func(e types.ProgressEvent) {
+		if e.Type == types.OperationStarted {
+			phase := e.Phase
+			if phase == "" {
+				phase = types.Provisioning
+			}
+			c := *cluster
+			c.ClusterInfo = &types.ClusterInfo{Status: &types.ClusterStatus{Phase: phase}}
+			if err := saveState(ctx, o, &c, provider); err != nil {
+				o.Log().Warn("unable to save the state of the created cluster", "cluster", cluster.Name, "error", err)
+			}
+		}
+		if next != nil {
+			next(e)
+		}
+	}
+}
+
+// deleteState removes the stored state of a deprovisioned cluster.
+func deleteState(ctx context.Context, o *types.Options, cluster *types.Cluster, provider *types.Provider) error {
+	if err := stateStore(o).Delete(ctx, clusterKey(cluster, provider)); err != nil {
+		return fmt.Errorf("unable to delete the state of cluster %s: %w", cluster.Name, err)
+	}
+	return nil
+}
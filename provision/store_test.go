@@ -0,0 +1,83 @@
+package provision
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/hydroform/provision/types"
+)
+
+func TestState(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	o := options([]types.Option{types.WithDataDir(t.TempDir())})
+
+	cluster := &types.Cluster{
+		Name:        "hydro",
+		NodeCount:   3,
+		ClusterInfo: &types.ClusterInfo{Endpoint: "https://api.hydro.example.com"},
+	}
+	minimum := 2
+	provider := &types.Provider{
+		Type:        types.Gardener,
+		ProjectName: "my-project",
+		Gardener:    &types.GardenerConfig{TargetProvider: types.GCP, WorkerMinimum: &minimum},
+	}
+
+	identity := &types.Provider{Type: types.Gardener, ProjectName: "my-project"}
+
+	_, _, err := loadState(ctx, o, &types.Cluster{Name: "hydro"}, nil, false)
+	require.Error(t, err, "Loading should fail without a provider")
+
+	// without persistence nothing is stored
+	require.NoError(t, saveState(ctx, o, cluster, provider))
+	cl, _, err := loadState(ctx, o, &types.Cluster{Name: "hydro"}, identity, false)
+	require.NoError(t, err)
+	require.Nil(t, cl.ClusterInfo)
+
+	o.Persistent = true
+	require.NoError(t, saveState(ctx, o, cluster, provider))
+
+	// a cluster referred to by name and provider identity uses the stored cluster and provider
+	cl, p, err := loadState(ctx, o, &types.Cluster{Name: "hydro"}, identity, false)
+	require.NoError(t, err)
+	require.Equal(t, cluster, cl)
+	require.Equal(t, types.Gardener, p.Type)
+	require.Nil(t, p.Gardener, "The typed configuration should be stored as custom configurations")
+	require.EqualValues(t, 2, p.CustomConfigurations["worker_minimum"])
+
+	// the cluster of the caller is kept for updates
+	desired := &types.Cluster{Name: "hydro", NodeCount: 5}
+	cl, p, err = loadState(ctx, o, desired, provider, true)
+	require.NoError(t, err)
+	require.Equal(t, 5, cl.NodeCount)
+	require.Equal(t, cluster.ClusterInfo, cl.ClusterInfo)
+	require.Same(t, provider, p, "A given provider should not be replaced")
+	require.Nil(t, desired.ClusterInfo, "The cluster of the caller should not be changed")
+
+	// a cluster with ClusterInfo is used as it is
+	other := &types.Cluster{Name: "hydro", ClusterInfo: &types.ClusterInfo{}}
+	cl, _, err = loadState(ctx, o, other, provider, false)
+	require.NoError(t, err)
+	require.Same(t, other, cl)
+
+	// the same name of another provider or project is another cluster
+	cl, _, err = loadState(ctx, o, &types.Cluster{Name: "hydro"}, &types.Provider{Type: types.Kind, ProjectName: "my-project"}, false)
+	require.NoError(t, err)
+	require.Nil(t, cl.ClusterInfo)
+	cl, _, err = loadState(ctx, o, &types.Cluster{Name: "hydro"}, &types.Provider{Type: types.Gardener, ProjectName: "other"}, false)
+	require.NoError(t, err)
+	require.Nil(t, cl.ClusterInfo)
+
+	// a stored state of another provider is rejected
+	require.NoError(t, stateStore(o).Save(ctx, "kind/my-project/hydro", &types.State{Cluster: cluster, Provider: provider}))
+	_, _, err = loadState(ctx, o, &types.Cluster{Name: "hydro"}, &types.Provider{Type: types.Kind, ProjectName: "my-project"}, false)
+	require.EqualError(t, err, `the stored state of cluster hydro does not belong to provider kind in project "my-project"`)
+
+	require.NoError(t, deleteState(ctx, o, cluster, provider))
+	cl, _, err = loadState(ctx, o, &types.Cluster{Name: "hydro"}, identity, false)
+	require.NoError(t, err)
+	require.Nil(t, cl.ClusterInfo, "The state should be gone after deleting it")
+}
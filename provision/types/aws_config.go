@@ -0,0 +1,50 @@
+package types
+
+// AWSConfig is the typed configuration of an AWS (EKS) provider.
+// The config tag of each field names its key in Provider.CustomConfigurations.
+type AWSConfig struct {
+	// RoleArn is the IAM role the EKS control plane uses.
+	RoleArn string `json:"roleArn,omitempty" yaml:"roleArn,omitempty" config:"role_arn"`
+	// NodeRoleArn is the IAM role of the worker nodes.
+	NodeRoleArn string `json:"nodeRoleArn,omitempty" yaml:"nodeRoleArn,omitempty" config:"node_role_arn"`
+	// SubnetIDs lists the subnets of the cluster and its nodes.
+	SubnetIDs []string `json:"subnetIDs,omitempty" yaml:"subnetIDs,omitempty" config:"subnet_ids"`
+	// SecurityGroupIDs lists additional security groups of the cluster.
+	SecurityGroupIDs []string `json:"securityGroupIDs,omitempty" yaml:"securityGroupIDs,omitempty" config:"security_group_ids"`
+	// NodegroupName is the name of the managed node group. Defaults to <cluster name>-workers.
+	NodegroupName string `json:"nodegroupName,omitempty" yaml:"nodegroupName,omitempty" config:"nodegroup_name"`
+	// WorkerMinimum and WorkerMaximum scale the node group. Both default to Cluster.NodeCount.
+	WorkerMinimum *int `json:"workerMinimum,omitempty" yaml:"workerMinimum,omitempty" config:"worker_minimum"`
+	WorkerMaximum *int `json:"workerMaximum,omitempty" yaml:"workerMaximum,omitempty" config:"worker_maximum"`
+	// Profile is the profile in the shared credentials file. Defaults to "default".
+	Profile string `json:"profile,omitempty" yaml:"profile,omitempty" config:"profile"`
+}
+
+// Map converts the configuration into the key-value format of Provider.CustomConfigurations.
+func (c *AWSConfig) Map() map[string]interface{} {
+	m := map[string]interface{}{}
+	toMap(c, m)
+	return m
+}
+
+func (c *AWSConfig) decode(m map[string]interface{}) error {
+	return fromMap(m, c)
+}
+
+// Validate checks that the IAM roles and subnets are set.
+func (c *AWSConfig) Validate() error {
+	vErr := &ValidationError{}
+	if c.RoleArn == "" {
+		vErr.add(cannotBeEmpty, customField("role_arn"))
+	}
+	if c.NodeRoleArn == "" {
+		vErr.add(cannotBeEmpty, customField("node_role_arn"))
+	}
+	if len(c.SubnetIDs) == 0 {
+		vErr.add(cannotBeEmpty, customField("subnet_ids"))
+	}
+	if c.WorkerMinimum != nil && c.WorkerMaximum != nil && *c.WorkerMaximum < *c.WorkerMinimum {
+		vErr.add("%s cannot be less than %s", customField("worker_maximum"), customField("worker_minimum"))
+	}
+	return vErr.errOrNil()
+}
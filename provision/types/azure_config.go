@@ -0,0 +1,44 @@
+package types
+
+// AzureConfig is the typed configuration of an Azure (AKS) provider.
+// The config tag of each field names its key in Provider.CustomConfigurations.
+type AzureConfig struct {
+	// SubscriptionID, TenantID, ClientID, and ClientSecret identify the service principal Hydroform authenticates with.
+	// The values that are not set are read from the JSON file in Provider.CredentialsFilePath.
+	SubscriptionID string `json:"subscriptionID,omitempty" yaml:"subscriptionID,omitempty" config:"subscription_id"`
+	TenantID       string `json:"tenantID,omitempty" yaml:"tenantID,omitempty" config:"tenant_id"`
+	ClientID       string `json:"clientID,omitempty" yaml:"clientID,omitempty" config:"client_id"`
+	ClientSecret   string `json:"clientSecret,omitempty" yaml:"clientSecret,omitempty" config:"client_secret"`
+	// ResourceGroup is the resource group of the cluster. Defaults to Provider.ProjectName.
+	ResourceGroup string `json:"resourceGroup,omitempty" yaml:"resourceGroup,omitempty" config:"resource_group"`
+}
+
+// Map converts the configuration into the key-value format of Provider.CustomConfigurations.
+func (c *AzureConfig) Map() map[string]interface{} {
+	m := map[string]interface{}{}
+	toMap(c, m)
+	return m
+}
+
+func (c *AzureConfig) decode(m map[string]interface{}) error {
+	return fromMap(m, c)
+}
+
+// Validate checks that the credentials of the service principal are set.
+// The Azure provisioner validates the configuration after filling in the credentials from the credentials file.
+func (c *AzureConfig) Validate() error {
+	vErr := &ValidationError{}
+	if c.SubscriptionID == "" {
+		vErr.add(cannotBeEmpty, customField("subscription_id"))
+	}
+	if c.TenantID == "" {
+		vErr.add(cannotBeEmpty, customField("tenant_id"))
+	}
+	if c.ClientID == "" {
+		vErr.add(cannotBeEmpty, customField("client_id"))
+	}
+	if c.ClientSecret == "" {
+		vErr.add(cannotBeEmpty, customField("client_secret"))
+	}
+	return vErr.errOrNil()
+}
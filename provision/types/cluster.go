@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 // Cluster contains detailed cluster specification and properties.
 type Cluster struct {
 	// Name specifies the unique name used to identify the cluster.
@@ -15,10 +17,69 @@ type Cluster struct {
 	// MachineType specifies the hardware cluster is provisioned on.
 	MachineType string `json:"machineType"`
 	// Location specifies the location of the actual cluster.
-	Location    string       `json:"location"`
+	Location string `json:"location"`
+	// WorkerPools specifies separate groups of worker nodes. Each pool has its own machines, scaling and scheduling settings.
+	// If empty, a single pool is created from the cluster and provider settings. Only supported for Gardener clusters.
+	WorkerPools []WorkerPool `json:"workerPools,omitempty"`
 	ClusterInfo *ClusterInfo `json:"clusterInfo"`
 }
 
+// WorkerPool contains the specification of a group of worker nodes.
+// Fields that are not set fall back to the cluster-wide settings.
+type WorkerPool struct {
+	// Name specifies the unique name of the pool within the cluster.
+	Name string `json:"name"`
+	// MachineType specifies the machine type of the nodes.
+	MachineType string `json:"machineType,omitempty"`
+	// MachineImageName specifies the operating system image of the nodes.
+	MachineImageName string `json:"machineImageName,omitempty"`
+	// MachineImageVersion specifies the version of the operating system image.
+	MachineImageVersion string `json:"machineImageVersion,omitempty"`
+	// Zones specifies the availability zones the nodes are spread across.
+	Zones []string `json:"zones,omitempty"`
+	// Minimum specifies the minimum number of nodes in the pool.
+	Minimum int `json:"minimum"`
+	// Maximum specifies the maximum number of nodes in the pool.
+	Maximum int `json:"maximum"`
+	// MaxSurge specifies the number of nodes that can be added above Maximum during a rolling update.
+	MaxSurge *int `json:"maxSurge,omitempty"`
+	// MaxUnavailable specifies the number of nodes that can be unavailable during a rolling update.
+	MaxUnavailable *int `json:"maxUnavailable,omitempty"`
+	// Labels specifies the Kubernetes labels added to every node of the pool.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Taints specifies the Kubernetes taints added to every node of the pool.
+	Taints []Taint `json:"taints,omitempty"`
+	// Volume specifies the root disk of the nodes.
+	Volume *Volume `json:"volume,omitempty"`
+}
+
+// Taint prevents pods that do not tolerate it from being scheduled on a node.
+type Taint struct {
+	Key    string      `json:"key"`
+	Value  string      `json:"value,omitempty"`
+	Effect TaintEffect `json:"effect"`
+}
+
+// TaintEffect specifies what happens to pods that do not tolerate a taint.
+type TaintEffect string
+
+const (
+	// TaintEffectNoSchedule prevents new pods from being scheduled on the node.
+	TaintEffectNoSchedule TaintEffect = "NoSchedule"
+	// TaintEffectPreferNoSchedule avoids scheduling new pods on the node if possible.
+	TaintEffectPreferNoSchedule TaintEffect = "PreferNoSchedule"
+	// TaintEffectNoExecute evicts running pods and prevents new pods from being scheduled on the node.
+	TaintEffectNoExecute TaintEffect = "NoExecute"
+)
+
+// Volume specifies the disk of a node.
+type Volume struct {
+	// SizeGB specifies the size of the disk in GB.
+	SizeGB int `json:"sizeGB,omitempty"`
+	// Type specifies the provider-specific type of the disk.
+	Type string `json:"type,omitempty"`
+}
+
 // ClusterInfo contains the actual provider-related cluster details retrieved after the cluster was provisioned.
 type ClusterInfo struct {
 	// Endpoint specifies the URL at which you can reach the cluster.
@@ -26,21 +87,94 @@ type ClusterInfo struct {
 	// CertificateAuthorityData contains certificates required to access the cluster.
 	CertificateAuthorityData []byte         `json:"certificateAuthorityData"`
 	Status                   *ClusterStatus `json:"status"`
+	// UID is the unique identifier the provider assigned to the cluster.
+	UID string `json:"uid,omitempty"`
+	// SeedName is the name of the Gardener seed cluster that hosts the control plane. Only set for Gardener clusters.
+	SeedName string `json:"seedName,omitempty"`
+	// TechnicalID is the Gardener technical ID used for the seed namespace and the infrastructure resources.
+	// Only set for Gardener clusters.
+	TechnicalID string `json:"technicalID,omitempty"`
+	// CreatedAt is when the provider created the cluster.
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+	// CreatedBy is the user who created the cluster, as reported by the provider. Only set for Gardener clusters.
+	CreatedBy string `json:"createdBy,omitempty"`
+	// Labels are the labels of the cluster at the provider.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ListFilter selects the clusters returned by List. An empty filter selects all clusters of the project.
+type ListFilter struct {
+	// Labels selects the clusters that have all of the given labels.
+	Labels map[string]string
+	// Annotations selects the clusters that have all of the given annotations.
+	Annotations map[string]string
 }
 
 // ClusterStatus contains possible values used to indicate the current cluster status.
 type ClusterStatus struct {
 	Phase Phase `json:"phase"`
+	// Progress is the completion percentage of the last operation performed on the cluster.
+	Progress int `json:"progress,omitempty"`
+	// Description describes the last operation performed on the cluster.
+	Description string `json:"description,omitempty"`
+	// Conditions contains the health conditions reported for the cluster.
+	Conditions []Condition `json:"conditions,omitempty"`
 }
 
 // Phase indicates the current status of the cluster.
 type Phase string
 
 const (
+	// Provisioning indicates that the cluster is being created.
+	Provisioning Phase = "Provisioning"
 	// Provisioned indicates that the cluster has been created and is fully usable.
 	Provisioned Phase = "Provisioned"
+	// Reconciling indicates that the cluster is being updated or reconciled.
+	Reconciling Phase = "Reconciling"
+	// Deprovisioning indicates that the cluster is being deleted.
+	Deprovisioning Phase = "Deprovisioning"
+	// Hibernated indicates that the cluster is hibernated and its nodes are scaled down.
+	Hibernated Phase = "Hibernated"
 	// Errored indicates that the cluster may be unusable due to errors.
 	Errored Phase = "Errored"
+	// Failed indicates that the last operation failed permanently and is not retried.
+	Failed Phase = "Failed"
 	// Unknown indicates that the cluster status is not known.
 	Unknown Phase = "Unknown"
 )
+
+// Condition describes one aspect of the health of a cluster.
+type Condition struct {
+	Type   ConditionType   `json:"type"`
+	Status ConditionStatus `json:"status"`
+	// Reason is a machine-readable reason for the last status change.
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message with details about the last status change.
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is the time the status last changed.
+	LastTransitionTime time.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// ConditionType is the type of a cluster health condition.
+type ConditionType string
+
+const (
+	// APIServerAvailable indicates whether the API server of the cluster is reachable.
+	APIServerAvailable ConditionType = "APIServerAvailable"
+	// ControlPlaneHealthy indicates whether the control plane components of the cluster are healthy.
+	ControlPlaneHealthy ConditionType = "ControlPlaneHealthy"
+	// EveryNodeReady indicates whether all nodes of the cluster are ready.
+	EveryNodeReady ConditionType = "EveryNodeReady"
+	// SystemComponentsHealthy indicates whether the system components running in the cluster are healthy.
+	SystemComponentsHealthy ConditionType = "SystemComponentsHealthy"
+)
+
+// ConditionStatus is the status of a cluster health condition.
+type ConditionStatus string
+
+const (
+	ConditionTrue        ConditionStatus = "True"
+	ConditionFalse       ConditionStatus = "False"
+	ConditionProgressing ConditionStatus = "Progressing"
+	ConditionUnknown     ConditionStatus = "Unknown"
+)
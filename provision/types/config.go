@@ -0,0 +1,277 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// cannotBeEmpty is the validation message for required fields.
+const cannotBeEmpty = "%s cannot be empty"
+
+// configTag is the struct tag that maps a field of a typed provider configuration to its key in Provider.CustomConfigurations.
+const configTag = "config"
+
+// ProviderConfig is the typed form of the custom configurations of a provider.
+type ProviderConfig interface {
+	// Map converts the configuration into the key-value format of Provider.CustomConfigurations.
+	// Fields that are not set are omitted.
+	Map() map[string]interface{}
+	// Validate checks the configuration. The returned *ValidationError names every invalid field.
+	Validate() error
+
+	decode(m map[string]interface{}) error
+}
+
+// ValidationError lists the problems found in a configuration. Each message names the offending field.
+type ValidationError struct {
+	Messages []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n - " + strings.Join(e.Messages, "\n - ")
+}
+
+func (e *ValidationError) add(format string, args ...interface{}) {
+	e.Messages = append(e.Messages, fmt.Sprintf(format, args...))
+}
+
+// errOrNil returns the error if it contains any message.
+func (e *ValidationError) errOrNil() error {
+	if len(e.Messages) == 0 {
+		return nil
+	}
+	return e
+}
+
+// customField returns the name of a custom configuration key as used in validation messages.
+func customField(key string) string {
+	return fmt.Sprintf("Provider.CustomConfigurations['%s']", key)
+}
+
+// Config returns the typed configuration of the provider.
+// It is built from CustomConfigurations, with the values of the typed field of the provider (for example, Gardener) taking precedence.
+// Values of the wrong type result in a *ValidationError. Providers without custom configurations return nil.
+func (p *Provider) Config() (ProviderConfig, error) {
+	var cfg, typed ProviderConfig
+	switch p.Type {
+	case Gardener:
+		cfg = &GardenerConfig{}
+		if p.Gardener != nil {
+			typed = p.Gardener
+		}
+	case AWS:
+		cfg = &AWSConfig{}
+		if p.AWS != nil {
+			typed = p.AWS
+		}
+	case Kind:
+		cfg = &KindConfig{}
+		if p.Kind != nil {
+			typed = p.Kind
+		}
+	case GCP:
+		cfg = &GCPConfig{}
+		if p.GCP != nil {
+			typed = p.GCP
+		}
+	case Azure:
+		cfg = &AzureConfig{}
+		if p.Azure != nil {
+			typed = p.Azure
+		}
+	default:
+		return nil, nil
+	}
+
+	m := map[string]interface{}{}
+	for k, v := range p.CustomConfigurations {
+		m[k] = v
+	}
+	if typed != nil {
+		for k, v := range typed.Map() {
+			m[k] = v
+		}
+	}
+
+	if err := cfg.decode(m); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Configurations returns the custom configurations of the provider merged with its typed configuration.
+// Known keys hold values of the types Hydroform expects, for example []string instead of the []interface{} decoded from JSON or YAML.
+// Unknown keys are kept unchanged.
+func (p *Provider) Configurations() (map[string]interface{}, error) {
+	cfg, err := p.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	m := map[string]interface{}{}
+	for k, v := range p.CustomConfigurations {
+		m[k] = v
+	}
+	if cfg != nil {
+		for k, v := range cfg.Map() {
+			m[k] = v
+		}
+	}
+	return m, nil
+}
+
+/*-- Conversion from and to the legacy configuration map --*/
+
+// fromMap sets the fields of the struct pointed to by out from the values of m, using the config tags of the fields.
+// Values are converted to the field types where possible. Every value that cannot be converted is reported.
+func fromMap(m map[string]interface{}, out interface{}) error {
+	vErr := &ValidationError{}
+	v := reflect.ValueOf(out).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key := t.Field(i).Tag.Get(configTag)
+		if key == "" {
+			continue
+		}
+		raw, ok := m[key]
+		if !ok || raw == nil {
+			continue
+		}
+		if err := setValue(v.Field(i), raw); err != nil {
+			vErr.add("%s %s", customField(key), err)
+		}
+	}
+	return vErr.errOrNil()
+}
+
+func setValue(field reflect.Value, raw interface{}) error {
+	if field.Kind() == reflect.Ptr {
+		elem := reflect.New(field.Type().Elem())
+		if err := setValue(elem.Elem(), raw); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		s, ok := raw.(string)
+		if !ok {
+			if rv := reflect.ValueOf(raw); rv.Kind() == reflect.String {
+				s, ok = rv.String(), true
+			}
+		}
+		if !ok {
+			return fmt.Errorf("must be a string, got %T", raw)
+		}
+		field.SetString(s)
+	case reflect.Int:
+		i, ok := toInt(raw)
+		if !ok {
+			return fmt.Errorf("must be an integer, got %v", raw)
+		}
+		field.SetInt(int64(i))
+	case reflect.Bool:
+		b, ok := raw.(bool)
+		if !ok {
+			return fmt.Errorf("must be a boolean, got %T", raw)
+		}
+		field.SetBool(b)
+	case reflect.Slice:
+		s, ok := toStringSlice(raw)
+		if !ok {
+			return fmt.Errorf("must be a list of strings, got %T", raw)
+		}
+		field.Set(reflect.ValueOf(s))
+	case reflect.Map:
+		s, ok := toStringMap(raw)
+		if !ok {
+			return fmt.Errorf("must be a map of strings, got %T", raw)
+		}
+		field.Set(reflect.ValueOf(s))
+	default:
+		return fmt.Errorf("has an unsupported type %s", field.Type())
+	}
+	return nil
+}
+
+func toInt(raw interface{}) (int, bool) {
+	switch v := raw.(type) {
+	case int:
+		return v, true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case float64:
+		// numbers decoded from JSON
+		if v == math.Trunc(v) {
+			return int(v), true
+		}
+	}
+	return 0, false
+}
+
+func toStringSlice(raw interface{}) ([]string, bool) {
+	switch v := raw.(type) {
+	case []string:
+		return v, true
+	case []interface{}:
+		res := make([]string, 0, len(v))
+		for _, e := range v {
+			s, ok := e.(string)
+			if !ok {
+				return nil, false
+			}
+			res = append(res, s)
+		}
+		return res, true
+	}
+	return nil, false
+}
+
+func toStringMap(raw interface{}) (map[string]string, bool) {
+	switch v := raw.(type) {
+	case map[string]string:
+		return v, true
+	case map[string]interface{}:
+		res := make(map[string]string, len(v))
+		for k, e := range v {
+			s, ok := e.(string)
+			if !ok {
+				return nil, false
+			}
+			res[k] = s
+		}
+		return res, true
+	}
+	return nil, false
+}
+
+// toMap adds the fields of the struct pointed to by in that are set to m, using the config tags of the fields.
+// Pointers are dereferenced and string-based types are converted to string.
+func toMap(in interface{}, m map[string]interface{}) {
+	v := reflect.ValueOf(in).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key := t.Field(i).Tag.Get(configTag)
+		if key == "" {
+			continue
+		}
+		f := v.Field(i)
+		if f.IsZero() {
+			continue
+		}
+		if f.Kind() == reflect.Ptr {
+			f = f.Elem()
+		}
+		if f.Kind() == reflect.String {
+			m[key] = f.String()
+			continue
+		}
+		m[key] = f.Interface()
+	}
+}
@@ -0,0 +1,220 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func gardenerProvider(custom map[string]interface{}) *Provider {
+	return &Provider{
+		Type:                 Gardener,
+		CustomConfigurations: custom,
+	}
+}
+
+func TestConfigurations(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Values decoded from JSON", func(t *testing.T) {
+		t.Parallel()
+		var custom map[string]interface{}
+		err := json.Unmarshal([]byte(`{
+			"target_provider": "azure",
+			"target_secret": "secret",
+			"zones": ["1", "2"],
+			"worker_minimum": 1,
+			"worker_maximum": 3,
+			"privileged_containers": true,
+			"annotations": {"a": "b"},
+			"unknown_key": 42
+		}`), &custom)
+		require.NoError(t, err)
+
+		m, err := gardenerProvider(custom).Configurations()
+		require.NoError(t, err)
+		require.Equal(t, "azure", m["target_provider"])
+		require.Equal(t, []string{"1", "2"}, m["zones"])
+		require.Equal(t, 1, m["worker_minimum"])
+		require.Equal(t, 3, m["worker_maximum"])
+		require.Equal(t, true, m["privileged_containers"])
+		require.Equal(t, map[string]string{"a": "b"}, m["annotations"])
+		require.Equal(t, []string{""}, m["service_endpoints"], "Azure service endpoints should be defaulted")
+		require.Equal(t, float64(42), m["unknown_key"], "Unknown keys should be kept as they are")
+	})
+
+	t.Run("Typed configuration takes precedence", func(t *testing.T) {
+		t.Parallel()
+		minimum := 2
+		p := gardenerProvider(map[string]interface{}{
+			"target_provider": "gcp",
+			"target_secret":   "legacy",
+			"worker_minimum":  1,
+		})
+		p.Gardener = &GardenerConfig{
+			TargetSecret:   "typed",
+			WorkerMinimum:  &minimum,
+			GCP:            &GardenerGCPConfig{ControlPlaneZone: "europe-west3-a"},
+			Azure:          &GardenerAzureConfig{VnetCIDR: "10.0.0.0/16"},
+			NetworkingType: "calico",
+		}
+
+		cfg, err := p.Config()
+		require.NoError(t, err)
+		gardenerCfg := cfg.(*GardenerConfig)
+		require.Equal(t, GCP, gardenerCfg.TargetProvider)
+		require.Equal(t, "typed", gardenerCfg.TargetSecret)
+		require.Equal(t, 2, *gardenerCfg.WorkerMinimum)
+		require.Equal(t, "europe-west3-a", gardenerCfg.GCP.ControlPlaneZone)
+		require.Nil(t, gardenerCfg.Azure, "Only the settings of the target provider should be read")
+	})
+
+	t.Run("Values of the wrong type", func(t *testing.T) {
+		t.Parallel()
+		_, err := gardenerProvider(map[string]interface{}{
+			"target_provider": "gcp",
+			"zones":           "europe-west3-a",
+			"worker_minimum":  1.5,
+			"workercidr":      []interface{}{"10.250.0.0/19"},
+		}).Configurations()
+		require.Error(t, err)
+
+		vErr, ok := err.(*ValidationError)
+		require.True(t, ok)
+		require.Len(t, vErr.Messages, 3)
+		require.Contains(t, vErr.Messages[0], "Provider.CustomConfigurations['worker_minimum']")
+		require.Contains(t, vErr.Messages[1], "Provider.CustomConfigurations['zones']")
+		require.Contains(t, vErr.Messages[2], "Provider.CustomConfigurations['workercidr']")
+	})
+
+	t.Run("Provider without custom configurations", func(t *testing.T) {
+		t.Parallel()
+		custom := map[string]interface{}{"key": "value"}
+		p := &Provider{Type: "custom", CustomConfigurations: custom}
+
+		cfg, err := p.Config()
+		require.NoError(t, err)
+		require.Nil(t, cfg)
+
+		m, err := p.Configurations()
+		require.NoError(t, err)
+		require.Equal(t, custom, m)
+	})
+}
+
+func TestGardenerConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	one := 1
+	valid := func() *GardenerConfig {
+		return &GardenerConfig{
+			TargetProvider:       GCP,
+			TargetSecret:         "secret",
+			DiskType:             "pd-standard",
+			WorkerMaxSurge:       &one,
+			WorkerMaxUnavailable: &one,
+			NetworkingType:       "calico",
+			Zones:                []string{"europe-west3-a"},
+			GCP: &GardenerGCPConfig{
+				ControlPlaneZone: "europe-west3-a",
+				WorkerCIDR:       "10.250.0.0/19",
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(c *GardenerConfig)
+		fields []string
+	}{
+		{name: "Valid", modify: func(c *GardenerConfig) {}},
+		{
+			name:   "Unknown target provider",
+			modify: func(c *GardenerConfig) { c.TargetProvider = "openstack"; c.GCP = nil },
+			fields: []string{"target_provider"},
+		},
+		{
+			name:   "Unknown purpose",
+			modify: func(c *GardenerConfig) { c.Purpose = "fun" },
+			fields: []string{"purpose"},
+		},
+		{
+			name:   "Missing GCP settings",
+			modify: func(c *GardenerConfig) { c.GCP = &GardenerGCPConfig{}; c.Zones = nil },
+			fields: []string{"workercidr", "gcp_control_plane_zone", "zones"},
+		},
+		{
+			name: "Missing Azure settings",
+			modify: func(c *GardenerConfig) {
+				c.TargetProvider = Azure
+				c.GCP = nil
+				c.Azure = &GardenerAzureConfig{}
+			},
+			fields: []string{"workercidr", "vnetcidr", "machine_image_name", "machine_image_version"},
+		},
+		{
+			name:   "Maximum below minimum",
+			modify: func(c *GardenerConfig) { zero := 0; c.WorkerMinimum = &one; c.WorkerMaximum = &zero },
+			fields: []string{"worker_maximum"},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			c := valid()
+			tc.modify(c)
+
+			err := c.Validate()
+			if len(tc.fields) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			vErr := err.(*ValidationError)
+			require.Len(t, vErr.Messages, len(tc.fields))
+			for i, f := range tc.fields {
+				require.Contains(t, vErr.Messages[i], "Provider.CustomConfigurations['"+f+"']")
+			}
+		})
+	}
+}
+
+func TestAzureConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	p := &Provider{
+		Type: Azure,
+		CustomConfigurations: map[string]interface{}{
+			"subscription_id": "subscription",
+			"client_id":       "legacy",
+		},
+		Azure: &AzureConfig{ClientID: "client", ClientSecret: "secret"},
+	}
+	cfg, err := p.Config()
+	require.NoError(t, err)
+	require.Equal(t, "client", cfg.(*AzureConfig).ClientID, "The typed configuration should take precedence")
+
+	err = cfg.Validate()
+	require.Error(t, err)
+	vErr := err.(*ValidationError)
+	require.Equal(t, []string{"Provider.CustomConfigurations['tenant_id'] cannot be empty"}, vErr.Messages)
+
+	p.Azure.TenantID = "tenant"
+	cfg, err = p.Config()
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+}
+
+func TestGCPConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, (&GCPConfig{}).Validate())
+	require.NoError(t, (&GCPConfig{NodePoolName: "system-pool"}).Validate())
+
+	err := (&GCPConfig{NodePoolName: "System_Pool"}).Validate()
+	require.Error(t, err)
+	require.Contains(t, err.(*ValidationError).Messages[0], "Provider.CustomConfigurations['node_pool_name']")
+}
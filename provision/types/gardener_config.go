@@ -0,0 +1,196 @@
+package types
+
+// GardenerConfig is the typed configuration of a Gardener provider.
+// The config tag of each field names its key in Provider.CustomConfigurations.
+type GardenerConfig struct {
+	// TargetProvider specifies the infrastructure Gardener creates the cluster on: gcp, azure, or aws.
+	TargetProvider ProviderType `json:"targetProvider,omitempty" yaml:"targetProvider,omitempty" config:"target_provider"`
+	// TargetSecret is the name of the secret binding with the credentials of the target provider.
+	TargetSecret string `json:"targetSecret,omitempty" yaml:"targetSecret,omitempty" config:"target_secret"`
+	// SeedName pins the cluster to a seed. If empty, Gardener schedules the cluster.
+	SeedName string `json:"seedName,omitempty" yaml:"seedName,omitempty" config:"seed_name"`
+	// Purpose is the purpose of the cluster: evaluation, testing, development, production, or infrastructure.
+	Purpose string `json:"purpose,omitempty" yaml:"purpose,omitempty" config:"purpose"`
+
+	DiskType             string `json:"diskType,omitempty" yaml:"diskType,omitempty" config:"disk_type"`
+	WorkerMinimum        *int   `json:"workerMinimum,omitempty" yaml:"workerMinimum,omitempty" config:"worker_minimum"`
+	WorkerMaximum        *int   `json:"workerMaximum,omitempty" yaml:"workerMaximum,omitempty" config:"worker_maximum"`
+	WorkerMaxSurge       *int   `json:"workerMaxSurge,omitempty" yaml:"workerMaxSurge,omitempty" config:"worker_max_surge"`
+	WorkerMaxUnavailable *int   `json:"workerMaxUnavailable,omitempty" yaml:"workerMaxUnavailable,omitempty" config:"worker_max_unavailable"`
+	MachineImageName     string `json:"machineImageName,omitempty" yaml:"machineImageName,omitempty" config:"machine_image_name"`
+	MachineImageVersion  string `json:"machineImageVersion,omitempty" yaml:"machineImageVersion,omitempty" config:"machine_image_version"`
+	// Zones lists the availability zones of the workers.
+	Zones []string `json:"zones,omitempty" yaml:"zones,omitempty" config:"zones"`
+
+	NetworkingType  string `json:"networkingType,omitempty" yaml:"networkingType,omitempty" config:"networking_type"`
+	NetworkingNodes string `json:"networkingNodes,omitempty" yaml:"networkingNodes,omitempty" config:"networking_nodes"`
+
+	PrivilegedContainers *bool             `json:"privilegedContainers,omitempty" yaml:"privilegedContainers,omitempty" config:"privileged_containers"`
+	Annotations          map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty" config:"annotations"`
+	// Labels are added to the shoot, for example to find the clusters created by a tool with List.
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty" config:"labels"`
+
+	HibernationStart    string `json:"hibernationStart,omitempty" yaml:"hibernationStart,omitempty" config:"hibernation_start"`
+	HibernationEnd      string `json:"hibernationEnd,omitempty" yaml:"hibernationEnd,omitempty" config:"hibernation_end"`
+	HibernationLocation string `json:"hibernationLocation,omitempty" yaml:"hibernationLocation,omitempty" config:"hibernation_location"`
+
+	OIDCCABundle       string            `json:"oidcCABundle,omitempty" yaml:"oidcCABundle,omitempty" config:"oidc_ca_bundle"`
+	OIDCClientID       string            `json:"oidcClientID,omitempty" yaml:"oidcClientID,omitempty" config:"oidc_client_id"`
+	OIDCGroupsClaim    string            `json:"oidcGroupsClaim,omitempty" yaml:"oidcGroupsClaim,omitempty" config:"oidc_groups_claim"`
+	OIDCGroupsPrefix   string            `json:"oidcGroupsPrefix,omitempty" yaml:"oidcGroupsPrefix,omitempty" config:"oidc_groups_prefix"`
+	OIDCIssuerURL      string            `json:"oidcIssuerURL,omitempty" yaml:"oidcIssuerURL,omitempty" config:"oidc_issuer_url"`
+	OIDCRequiredClaims map[string]string `json:"oidcRequiredClaims,omitempty" yaml:"oidcRequiredClaims,omitempty" config:"oidc_required_claims"`
+	OIDCSigningAlgs    []string          `json:"oidcSigningAlgs,omitempty" yaml:"oidcSigningAlgs,omitempty" config:"oidc_signing_algs"`
+	OIDCUsernameClaim  string            `json:"oidcUsernameClaim,omitempty" yaml:"oidcUsernameClaim,omitempty" config:"oidc_username_claim"`
+	OIDCUsernamePrefix string            `json:"oidcUsernamePrefix,omitempty" yaml:"oidcUsernamePrefix,omitempty" config:"oidc_username_prefix"`
+
+	// GCP holds the settings specific to the gcp target provider.
+	GCP *GardenerGCPConfig `json:"gcp,omitempty" yaml:"gcp,omitempty"`
+	// AWS holds the settings specific to the aws target provider.
+	AWS *GardenerAWSConfig `json:"aws,omitempty" yaml:"aws,omitempty"`
+	// Azure holds the settings specific to the azure target provider.
+	Azure *GardenerAzureConfig `json:"azure,omitempty" yaml:"azure,omitempty"`
+}
+
+// GardenerGCPConfig holds the Gardener settings specific to GCP.
+type GardenerGCPConfig struct {
+	// ControlPlaneZone is the zone of the control plane.
+	ControlPlaneZone string `json:"controlPlaneZone,omitempty" yaml:"controlPlaneZone,omitempty" config:"gcp_control_plane_zone"`
+	// WorkerCIDR is the CIDR of the worker network.
+	WorkerCIDR string `json:"workerCIDR,omitempty" yaml:"workerCIDR,omitempty" config:"workercidr"`
+}
+
+// GardenerAWSConfig holds the Gardener settings specific to AWS.
+type GardenerAWSConfig struct {
+	// VnetCIDR is the CIDR of the VPC. The subnets of the zones are generated from it.
+	VnetCIDR string `json:"vnetCIDR,omitempty" yaml:"vnetCIDR,omitempty" config:"vnetcidr"`
+}
+
+// GardenerAzureConfig holds the Gardener settings specific to Azure.
+type GardenerAzureConfig struct {
+	// VnetCIDR is the CIDR of the virtual network.
+	VnetCIDR string `json:"vnetCIDR,omitempty" yaml:"vnetCIDR,omitempty" config:"vnetcidr"`
+	// WorkerCIDR is the CIDR of the worker network.
+	WorkerCIDR string `json:"workerCIDR,omitempty" yaml:"workerCIDR,omitempty" config:"workercidr"`
+	// ServiceEndpoints lists the service endpoints of the worker network.
+	ServiceEndpoints []string `json:"serviceEndpoints,omitempty" yaml:"serviceEndpoints,omitempty" config:"service_endpoints"`
+}
+
+// Map converts the configuration into the key-value format of Provider.CustomConfigurations.
+func (c *GardenerConfig) Map() map[string]interface{} {
+	m := map[string]interface{}{}
+	toMap(c, m)
+	if c.GCP != nil {
+		toMap(c.GCP, m)
+	}
+	if c.AWS != nil {
+		toMap(c.AWS, m)
+	}
+	if c.Azure != nil {
+		toMap(c.Azure, m)
+	}
+	return m
+}
+
+func (c *GardenerConfig) decode(m map[string]interface{}) error {
+	vErr := &ValidationError{}
+	if err := fromMap(m, c); err != nil {
+		vErr.Messages = append(vErr.Messages, err.(*ValidationError).Messages...)
+	}
+
+	// only the settings of the chosen target provider are read
+	var target interface{}
+	switch c.TargetProvider {
+	case GCP:
+		c.GCP = &GardenerGCPConfig{}
+		target = c.GCP
+	case AWS:
+		c.AWS = &GardenerAWSConfig{}
+		target = c.AWS
+	case Azure:
+		c.Azure = &GardenerAzureConfig{}
+		target = c.Azure
+	}
+	if target != nil {
+		if err := fromMap(m, target); err != nil {
+			vErr.Messages = append(vErr.Messages, err.(*ValidationError).Messages...)
+		}
+	}
+
+	if c.Azure != nil && c.Azure.ServiceEndpoints == nil {
+		c.Azure.ServiceEndpoints = []string{""}
+	}
+	return vErr.errOrNil()
+}
+
+// Validate checks that all settings required by the target provider are set.
+// The worker scaling is not checked, as it is not needed if the cluster specifies worker pools.
+func (c *GardenerConfig) Validate() error {
+	vErr := &ValidationError{}
+
+	switch c.TargetProvider {
+	case GCP, AWS, Azure:
+	case "":
+		vErr.add(cannotBeEmpty, customField("target_provider"))
+	default:
+		vErr.add("%s has to be one of: gcp, azure, aws", customField("target_provider"))
+	}
+	if c.TargetSecret == "" {
+		vErr.add(cannotBeEmpty, customField("target_secret"))
+	}
+	switch c.Purpose {
+	case "", "evaluation", "testing", "development", "production", "infrastructure":
+	default:
+		vErr.add("%s has to be one of: evaluation, testing, development, production, infrastructure", customField("purpose"))
+	}
+	if c.DiskType == "" {
+		vErr.add(cannotBeEmpty, customField("disk_type"))
+	}
+	if c.WorkerMaxSurge == nil {
+		vErr.add(cannotBeEmpty, customField("worker_max_surge"))
+	}
+	if c.WorkerMaxUnavailable == nil {
+		vErr.add(cannotBeEmpty, customField("worker_max_unavailable"))
+	}
+	if c.WorkerMinimum != nil && c.WorkerMaximum != nil && *c.WorkerMaximum < *c.WorkerMinimum {
+		vErr.add("%s cannot be less than %s", customField("worker_maximum"), customField("worker_minimum"))
+	}
+	if c.NetworkingType == "" {
+		vErr.add(cannotBeEmpty, customField("networking_type"))
+	}
+
+	switch {
+	case c.GCP != nil:
+		if c.GCP.WorkerCIDR == "" {
+			vErr.add(cannotBeEmpty, customField("workercidr"))
+		}
+		if c.GCP.ControlPlaneZone == "" {
+			vErr.add(cannotBeEmpty, customField("gcp_control_plane_zone"))
+		}
+		if len(c.Zones) == 0 {
+			vErr.add(cannotBeEmpty, customField("zones"))
+		}
+	case c.AWS != nil:
+		if c.AWS.VnetCIDR == "" {
+			vErr.add(cannotBeEmpty, customField("vnetcidr"))
+		}
+		if len(c.Zones) == 0 {
+			vErr.add(cannotBeEmpty, customField("zones"))
+		}
+	case c.Azure != nil:
+		if c.Azure.WorkerCIDR == "" {
+			vErr.add(cannotBeEmpty, customField("workercidr"))
+		}
+		if c.Azure.VnetCIDR == "" {
+			vErr.add(cannotBeEmpty, customField("vnetcidr"))
+		}
+		if c.MachineImageName == "" {
+			vErr.add(cannotBeEmpty, customField("machine_image_name"))
+		}
+		if c.MachineImageVersion == "" {
+			vErr.add(cannotBeEmpty, customField("machine_image_version"))
+		}
+	}
+
+	return vErr.errOrNil()
+}
@@ -0,0 +1,34 @@
+package types
+
+import "regexp"
+
+// gkeNamePattern matches the names GKE accepts for node pools.
+var gkeNamePattern = regexp.MustCompile(`^[a-z](?:[-a-z0-9]{0,38}[a-z0-9])?$`)
+
+// GCPConfig is the typed configuration of a GCP (GKE) provider.
+// The config tag of each field names its key in Provider.CustomConfigurations.
+type GCPConfig struct {
+	// NodePoolName is the name of the node pool created with the cluster. Defaults to default-pool.
+	NodePoolName string `json:"nodePoolName,omitempty" yaml:"nodePoolName,omitempty" config:"node_pool_name"`
+}
+
+// Map converts the configuration into the key-value format of Provider.CustomConfigurations.
+func (c *GCPConfig) Map() map[string]interface{} {
+	m := map[string]interface{}{}
+	toMap(c, m)
+	return m
+}
+
+func (c *GCPConfig) decode(m map[string]interface{}) error {
+	return fromMap(m, c)
+}
+
+// Validate checks the name of the node pool.
+func (c *GCPConfig) Validate() error {
+	vErr := &ValidationError{}
+	if c.NodePoolName != "" && !gkeNamePattern.MatchString(c.NodePoolName) {
+		vErr.add("%s must start with a lowercase letter followed by up to 39 lowercase letters, numbers, or hyphens, and cannot end with a hyphen",
+			customField("node_pool_name"))
+	}
+	return vErr.errOrNil()
+}
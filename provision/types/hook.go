@@ -0,0 +1,35 @@
+package types
+
+import "context"
+
+// HookOperation names the Hydroform operation a hook runs for.
+type HookOperation string
+
+const (
+	ProvisionHook   HookOperation = "provision"
+	StatusHook      HookOperation = "status"
+	CredentialsHook HookOperation = "credentials"
+	UpdateHook      HookOperation = "update"
+	HibernateHook   HookOperation = "hibernate"
+	WakeUpHook      HookOperation = "wakeup"
+	DeprovisionHook HookOperation = "deprovision"
+	ImportHook      HookOperation = "import"
+)
+
+// HookEvent describes the operation a hook runs for.
+type HookEvent struct {
+	// Operation is the name of the operation.
+	Operation HookOperation
+	// Cluster is the cluster of the operation. For a cluster referred to by its name, it is the cluster taken from the state store.
+	Cluster *Cluster
+	// Provider is the provider of the operation.
+	Provider *Provider
+	// Result is the result of the operation: a *Cluster, a *ClusterStatus, or the kubeconfig as []byte. It is nil for Deprovision and for the hooks run before the operation.
+	Result interface{}
+	// Err is the error of the operation. It is nil for the hooks run before the operation.
+	Err error
+}
+
+// Hook is a function run before or after a Hydroform operation.
+// Hooks are set for a single call with WithBefore and WithAfter, so concurrent calls do not share them.
+type Hook func(ctx context.Context, event HookEvent) error
@@ -0,0 +1,39 @@
+package types
+
+// KindConfig is the typed configuration of a kind provider.
+// The config tag of each field names its key in Provider.CustomConfigurations.
+type KindConfig struct {
+	// NodeImage is the node image of the cluster, for example kindest/node:v1.27.3.
+	NodeImage string `json:"nodeImage,omitempty" yaml:"nodeImage,omitempty" config:"node_image"`
+	// ControlPlaneNodes is the number of control plane nodes. Defaults to 1.
+	ControlPlaneNodes *int `json:"controlPlaneNodes,omitempty" yaml:"controlPlaneNodes,omitempty" config:"control_plane_nodes"`
+	// WorkerNodes is the number of worker nodes. If not set, the nodes of Cluster.NodeCount
+	// that are not control plane nodes become workers.
+	WorkerNodes *int `json:"workerNodes,omitempty" yaml:"workerNodes,omitempty" config:"worker_nodes"`
+	// ExtraPortMappings are added to the first control plane node, in the format
+	// [listenAddress:]hostPort:containerPort[/protocol].
+	ExtraPortMappings []string `json:"extraPortMappings,omitempty" yaml:"extraPortMappings,omitempty" config:"extra_port_mappings"`
+}
+
+// Map converts the configuration into the key-value format of Provider.CustomConfigurations.
+func (c *KindConfig) Map() map[string]interface{} {
+	m := map[string]interface{}{}
+	toMap(c, m)
+	return m
+}
+
+func (c *KindConfig) decode(m map[string]interface{}) error {
+	return fromMap(m, c)
+}
+
+// Validate checks the node counts.
+func (c *KindConfig) Validate() error {
+	vErr := &ValidationError{}
+	if c.ControlPlaneNodes != nil && *c.ControlPlaneNodes < 1 {
+		vErr.add("%s cannot be less than 1", customField("control_plane_nodes"))
+	}
+	if c.WorkerNodes != nil && *c.WorkerNodes < 0 {
+		vErr.add("%s cannot be less than 0", customField("worker_nodes"))
+	}
+	return vErr.errOrNil()
+}
@@ -0,0 +1,30 @@
+package types
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrLocked is returned if a cluster is still locked by another operation when the lock timeout is reached.
+	ErrLocked = errors.New("cluster is locked by another operation")
+	// ErrLockLost is returned if an operation was cancelled because the lock of its cluster could not be kept.
+	ErrLockLost = errors.New("cluster lock lost")
+)
+
+// Locker prevents concurrent operations that change the same cluster.
+// Hydroform holds the lock of a cluster while Provision, Update, Hibernate, WakeUp, or Deprovision run.
+// The lock of a cluster is identified by a key made of the provider type, the project name, and the cluster name.
+type Locker interface {
+	// Lock acquires the lock with the given key. It waits while another holder has the lock and fails with ErrLocked after the lock timeout.
+	Lock(ctx context.Context, key string) error
+	// Unlock releases the lock with the given key if it is held by this Locker.
+	Unlock(ctx context.Context, key string) error
+}
+
+// LockWatcher is implemented by lockers that notice when a held lock is lost, for example because it could not be renewed within its TTL.
+// Hydroform cancels an operation whose lock is lost with ErrLockLost, as another operation may have taken over the cluster.
+type LockWatcher interface {
+	// Lost returns a channel that is closed when the lock with the given key is lost. It returns nil if the lock is not held.
+	Lost(key string) <-chan struct{}
+}
@@ -1,16 +1,34 @@
 package types
 
-import "time"
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+)
 
 // Options contains all possible configuration options for Hydroform.
 // Options need to be set each time a Hydroform function is called
 type Options struct {
-	DataDir    string
-	Persistent bool
-	Timeouts   *Timeouts
-	Verbose    bool
+	DataDir          string
+	Persistent       bool
+	Timeouts         *Timeouts
+	PollingIntervals *PollingIntervals
+	Verbose          bool
+	StateStore       StateStore
+	Locker           Locker
+	Operator         OperatorType
+	BeforeHooks      []Hook
+	AfterHooks       []Hook
+	Progress         ProgressFunc
+	Logger           *slog.Logger
 }
 
+// OperatorType identifies an operator registered in the operator package.
+type OperatorType string
+
 // Timeouts specifies timeouts on various operation
 type Timeouts struct {
 	Create time.Duration
@@ -18,11 +36,134 @@ type Timeouts struct {
 	Delete time.Duration
 }
 
+// PollingIntervals specifies how often the state of the cluster is checked while waiting for an operation to finish.
+type PollingIntervals struct {
+	Create time.Duration
+	Update time.Duration
+	Delete time.Duration
+}
+
+// Operation identifies a long running operation on a cluster.
+type Operation string
+
+const (
+	// CreateOperation is the operation run by Provision.
+	CreateOperation Operation = "create"
+	// UpdateOperation is the operation run when an existing cluster is changed.
+	UpdateOperation Operation = "update"
+	// DeleteOperation is the operation run by Deprovision.
+	DeleteOperation Operation = "delete"
+	// HibernateOperation is the operation run by Hibernate. It uses the timeout and the polling interval of UpdateOperation.
+	HibernateOperation Operation = "hibernate"
+	// WakeUpOperation is the operation run by WakeUp. It uses the timeout and the polling interval of UpdateOperation.
+	WakeUpOperation Operation = "wakeup"
+)
+
+// defaultDataDir is the directory used if no DataDir is set, relative to the home directory of the user.
+const defaultDataDir = ".hydroform"
+
+// Dir returns the directory Hydroform keeps its files in.
+// It is the configured DataDir or, if none is set, the .hydroform directory in the home directory of the user.
+func (o *Options) Dir() string {
+	if o != nil && o.DataDir != "" {
+		return o.DataDir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), defaultDataDir)
+	}
+	return filepath.Join(home, defaultDataDir)
+}
+
+const (
+	// DefaultTimeout is used for operations without a configured timeout.
+	DefaultTimeout = 30 * time.Minute
+	// DefaultPollingInterval is used for operations without a configured polling interval.
+	DefaultPollingInterval = 15 * time.Second
+)
+
+// Timeout returns the configured timeout for the given operation or DefaultTimeout if none is set.
+func (o *Options) Timeout(op Operation) time.Duration {
+	if o == nil || o.Timeouts == nil {
+		return DefaultTimeout
+	}
+	var d time.Duration
+	switch op {
+	case CreateOperation:
+		d = o.Timeouts.Create
+	case UpdateOperation, HibernateOperation, WakeUpOperation:
+		d = o.Timeouts.Update
+	case DeleteOperation:
+		d = o.Timeouts.Delete
+	}
+	if d <= 0 {
+		return DefaultTimeout
+	}
+	return d
+}
+
+// PollingInterval returns the configured polling interval for the given operation or DefaultPollingInterval if none is set.
+func (o *Options) PollingInterval(op Operation) time.Duration {
+	if o == nil || o.PollingIntervals == nil {
+		return DefaultPollingInterval
+	}
+	var d time.Duration
+	switch op {
+	case CreateOperation:
+		d = o.PollingIntervals.Create
+	case UpdateOperation, HibernateOperation, WakeUpOperation:
+		d = o.PollingIntervals.Update
+	case DeleteOperation:
+		d = o.PollingIntervals.Delete
+	}
+	if d <= 0 {
+		return DefaultPollingInterval
+	}
+	return d
+}
+
+// Log returns the logger of the operations. It is the configured Logger or, if none is set, a logger that writes
+// debug logs to stderr in verbose mode and discards all logs otherwise. Hydroform never logs to stdout.
+func (o *Options) Log() *slog.Logger {
+	switch {
+	case o != nil && o.Logger != nil:
+		return o.Logger
+	case o != nil && o.Verbose:
+		return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	default:
+		return slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+}
+
+// ReportProgress passes the event to the configured ProgressFunc, if any. Events without a time get the current time.
+func (o *Options) ReportProgress(e ProgressEvent) {
+	if o == nil || o.Progress == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	o.Progress(e)
+}
+
+// TimeoutError is returned when an operation does not finish within its timeout.
+type TimeoutError struct {
+	// Operation is the operation that timed out.
+	Operation Operation
+	// Timeout is the timeout that was exceeded.
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s operation timed out after %s", e.Operation, e.Timeout)
+}
+
 // Option is a function that allows to extensibly configure Hydroform.
 type Option func(*Options)
 
 // Make hydroform configuration files stay in the file system after running.
 // By default files are always deleted after each call.
+// With persistence enabled, the state of provisioned clusters is kept in the state store.
 func Persistent() Option {
 	return func(ops *Options) {
 		ops.Persistent = true
@@ -36,12 +177,79 @@ func WithDataDir(dir string) Option {
 	}
 }
 
+// Set custom timeouts for the create, update and delete operations.
+// Operations without a timeout use DefaultTimeout.
 func WithTimeouts(timeouts *Timeouts) Option {
 	return func(ops *Options) {
 		ops.Timeouts = timeouts
 	}
 }
 
+// Set custom polling intervals for the create, update and delete operations.
+// Operations without a polling interval use DefaultPollingInterval.
+func WithPollingIntervals(intervals *PollingIntervals) Option {
+	return func(ops *Options) {
+		ops.PollingIntervals = intervals
+	}
+}
+
+// Set a custom store for the state of clusters, such as the Kubernetes Secret store of the state package.
+// By default the state is stored in files in DataDir.
+func WithStateStore(store StateStore) Option {
+	return func(ops *Options) {
+		ops.StateStore = store
+	}
+}
+
+// Set a custom locker that prevents concurrent changes of the same cluster, such as the Kubernetes Lease locker of the lock package.
+// By default, lock files in DataDir are used.
+func WithLocker(locker Locker) Option {
+	return func(ops *Options) {
+		ops.Locker = locker
+	}
+}
+
+// Select the operator that runs the operations, for example one registered with operator.Register.
+// By default the native operator is used.
+func WithOperator(t OperatorType) Option {
+	return func(ops *Options) {
+		ops.Operator = t
+	}
+}
+
+// Add hooks that run in the given order before the operation of the call.
+// If a hook fails, the operation is not run and the error is returned.
+func WithBefore(hooks ...Hook) Option {
+	return func(ops *Options) {
+		ops.BeforeHooks = append(ops.BeforeHooks, hooks...)
+	}
+}
+
+// Add hooks that run in the given order after the operation of the call, whether it succeeded or failed.
+// The event passed to the hooks holds the result and the error of the operation. All hooks run, even if one of them fails.
+func WithAfter(hooks ...Hook) Option {
+	return func(ops *Options) {
+		ops.AfterHooks = append(ops.AfterHooks, hooks...)
+	}
+}
+
+// Set a function that receives the progress of long running operations, such as the completion percentage and the state changes of a Gardener shoot.
+// To consume the events from a channel, send them to the channel in the function.
+func WithProgress(f ProgressFunc) Option {
+	return func(ops *Options) {
+		ops.Progress = f
+	}
+}
+
+// Set the logger that receives the structured logs of the operations, such as their start and end, the provider API calls, and the polling results.
+// The level of a log tells how detailed it is: the API calls and polling results are logged at the debug level.
+func WithLogger(l *slog.Logger) Option {
+	return func(ops *Options) {
+		ops.Logger = l
+	}
+}
+
+// Log the operations in detail to stderr if no logger is set with WithLogger.
 func Verbose(verbose bool) Option {
 	return func(ops *Options) {
 		ops.Verbose = verbose
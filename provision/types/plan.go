@@ -0,0 +1,40 @@
+package types
+
+import (
+	"encoding/json"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Plan is the resource that Provision would create for a cluster. It is rendered without contacting the provider.
+type Plan struct {
+	// Manifest is the rendered resource, such as the Gardener Shoot.
+	Manifest interface{}
+}
+
+// JSON renders the manifest of the plan as indented JSON.
+func (p *Plan) JSON() ([]byte, error) {
+	return json.MarshalIndent(p.Manifest, "", "  ")
+}
+
+// YAML renders the manifest of the plan as YAML.
+func (p *Plan) YAML() ([]byte, error) {
+	return yaml.Marshal(p.Manifest)
+}
+
+// PlanDiff is the difference between a Plan and the live resource of the cluster.
+type PlanDiff struct {
+	// Exists tells whether the live resource exists. If it does not, Provision would create the planned resource.
+	Exists bool
+	// Patch is the patch that Update would apply to the live resource. It is nil if Update would not change anything.
+	// Update only changes some settings, so the live resource can differ from the plan even if Patch is nil, for example in its region.
+	Patch []byte
+	// Diff is a unified diff of the live resource and the planned resource, in YAML.
+	// The live settings that the plan does not set, such as the defaults and the fields set by the provider, are left out.
+	Diff string
+}
+
+// Empty tells whether the live resource already matches the plan.
+func (d *PlanDiff) Empty() bool {
+	return d.Exists && d.Diff == ""
+}
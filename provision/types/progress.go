@@ -0,0 +1,45 @@
+package types
+
+import "time"
+
+// ProgressEventType is the kind of a ProgressEvent.
+type ProgressEventType string
+
+const (
+	// OperationStarted is emitted when the provider accepted the operation.
+	OperationStarted ProgressEventType = "Started"
+	// OperationProgressed is emitted when the completion percentage or the description of the operation changes.
+	OperationProgressed ProgressEventType = "Progressed"
+	// StateChanged is emitted when the phase of the cluster or the state of the operation changes.
+	StateChanged ProgressEventType = "StateChanged"
+	// LastErrorsReported is emitted when the provider reports new errors. The operation may still succeed after them.
+	LastErrorsReported ProgressEventType = "LastErrors"
+	// OperationCompleted is emitted when the operation finished, successfully or not.
+	OperationCompleted ProgressEventType = "Completed"
+)
+
+// ProgressEvent reports the progress of a long running operation on a cluster.
+type ProgressEvent struct {
+	Type ProgressEventType
+	// Operation is the operation the event belongs to.
+	Operation Operation
+	// Cluster is the name of the cluster.
+	Cluster string
+	// Time is when the event was emitted.
+	Time time.Time
+	// Phase is the phase of the cluster.
+	Phase Phase
+	// State is the state of the operation as reported by the provider, for example Processing or Succeeded.
+	State string
+	// Progress is the completion percentage of the operation.
+	Progress int
+	// Description describes what the provider is currently doing.
+	Description string
+	// LastErrors are the errors the provider reported for the operation.
+	LastErrors []string
+	// Err is the error the operation failed with. It is only set in OperationCompleted events.
+	Err error
+}
+
+// ProgressFunc receives the progress events of an operation. It is called synchronously from the goroutine running the operation, so it should return quickly.
+type ProgressFunc func(ProgressEvent)
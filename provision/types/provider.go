@@ -11,6 +11,17 @@ type Provider struct {
 	CredentialsFilePath string `json:"credentialsFilePath"`
 	// CustomConfigurations is a list of custom properties relevant for the chosen provider.
 	CustomConfigurations map[string]interface{} `json:"customConfigurations"`
+
+	// Gardener is the typed configuration of a Gardener provider. Its settings take precedence over CustomConfigurations.
+	Gardener *GardenerConfig `json:"gardener,omitempty"`
+	// AWS is the typed configuration of an AWS provider. Its settings take precedence over CustomConfigurations.
+	AWS *AWSConfig `json:"aws,omitempty"`
+	// Kind is the typed configuration of a kind provider. Its settings take precedence over CustomConfigurations.
+	Kind *KindConfig `json:"kind,omitempty"`
+	// GCP is the typed configuration of a GCP provider. Its settings take precedence over CustomConfigurations.
+	GCP *GCPConfig `json:"gcp,omitempty"`
+	// Azure is the typed configuration of an Azure provider. Its settings take precedence over CustomConfigurations.
+	Azure *AzureConfig `json:"azure,omitempty"`
 }
 
 // ProviderType lists available cloud providers.
@@ -0,0 +1,29 @@
+package types
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrStateNotFound is returned by a StateStore if no state is stored for a cluster.
+var ErrStateNotFound = errors.New("cluster state not found")
+
+// State is what Hydroform stores about a cluster between calls.
+type State struct {
+	// Cluster is the cluster including the ClusterInfo returned by the provider.
+	Cluster *Cluster `json:"cluster"`
+	// Provider is the provider the cluster was created with. Its CustomConfigurations hold the effective configuration.
+	Provider *Provider `json:"provider"`
+}
+
+// StateStore saves the state of clusters, so that Status, Credentials, Update, and Deprovision can find a cluster by its name and its provider.
+// The state of a cluster is identified by a key made of the provider type, the project name, and the cluster name, such as gardener/my-project/hydro,
+// as clusters of different providers or projects can have the same name.
+type StateStore interface {
+	// Save stores the state of the cluster with the given key, replacing any previous state.
+	Save(ctx context.Context, key string, state *State) error
+	// Load returns the state of the cluster with the given key or ErrStateNotFound if there is none.
+	Load(ctx context.Context, key string) (*State, error)
+	// Delete removes the state of the cluster with the given key. Deleting a state that does not exist is not an error.
+	Delete(ctx context.Context, key string) error
+}